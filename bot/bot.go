@@ -0,0 +1,86 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+// Package bot plays minesweeper games automatically to benchmark the strategies
+// of solving them. A Strategy chooses every move from what the player is allowed
+// to see, and the Run function plays a batch of games with reproducible seeds in
+// parallel goroutines.
+package bot
+
+import (
+	"github.com/rrborja/minesweeper"
+	"github.com/rrborja/minesweeper/solver"
+)
+
+// Action is the kind of a move.
+// Values of this type are bot.Visit, bot.Flag and bot.Chord
+type Action uint8
+
+const (
+	// Visit is the action of visiting a cell.
+	Visit Action = iota
+
+	// Flag is the action of flagging a cell, or of changing its mark.
+	Flag
+
+	// Chord is the action of chording a visited cell with a warning number.
+	Chord
+)
+
+// Move is the action chosen by a Strategy on the cell at the xy-coordinates.
+type Move struct {
+	Action
+	X, Y int
+
+	// Guess reports whether the strategy is not certain that the move is safe.
+	Guess bool
+}
+
+// Strategy chooses the next move of a game from the board as observed by the
+// player and the game's state, or returns the error of failing to choose one,
+// which abandons the game. A strategy is shared by the goroutines of the Run
+// function, so it must be safe for concurrent use.
+type Strategy interface {
+	Move(view minesweeper.BoardView, state minesweeper.GameState) (Move, error)
+}
+
+// StrategyFunc is an adapter to use an ordinary function as a Strategy.
+type StrategyFunc func(view minesweeper.BoardView, state minesweeper.GameState) (Move, error)
+
+// Move calls the function itself.
+func (strategy StrategyFunc) Move(view minesweeper.BoardView, state minesweeper.GameState) (Move, error) {
+	return strategy(view, state)
+}
+
+// Solver is the Strategy visiting the cells proven to be safe by the solver
+// package, the simplest deduction first, and guessing the cell least likely to
+// be a mine when no cell is proven to be safe. The mines are never flagged.
+// The solver.UnsupportedBoardError error is returned for the boards the solver
+// cannot read.
+var Solver Strategy = StrategyFunc(func(view minesweeper.BoardView, state minesweeper.GameState) (Move, error) {
+	board, err := solver.ReadBoard(view, state)
+	if err != nil {
+		return Move{}, err
+	}
+	hint, err := board.Hint()
+	if err != nil {
+		return Move{}, err
+	}
+	return Move{Action: Visit, X: hint.X, Y: hint.Y, Guess: !hint.Safe}, nil
+})
@@ -0,0 +1,178 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package bot
+
+import (
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/rrborja/minesweeper"
+)
+
+// movesPerCell limits the number of moves of a game to the number of cells
+// times the limit, so a strategy changing the marks of the cells back and forth
+// does not play forever
+const movesPerCell = 4
+
+// Result is the outcome of a game played by the Run function.
+type Result struct {
+	// Seed is the seed the game is created with, so the game can be replayed
+	// with the minesweeper.WithSeed(int64) option.
+	Seed int64
+
+	// Status is the status of the game once it is over or abandoned.
+	minesweeper.Status
+
+	// Moves is the number of moves chosen by the strategy.
+	Moves int
+
+	// Guesses is the number of moves the strategy is not certain about.
+	Guesses int
+
+	// Duration is the time taken to play the game.
+	Duration time.Duration
+
+	// Err is the error of the strategy or of the move abandoning the game,
+	// other than visiting a mine.
+	Err error
+}
+
+// Won reports whether the game is won.
+func (result Result) Won() bool {
+	return result.Status == minesweeper.Won
+}
+
+// Report is the summary of the games played by the Run function.
+type Report struct {
+	// Results are the results of the games in the order of their seeds.
+	Results []Result
+
+	// Wins is the number of games won.
+	Wins int
+
+	// WinRate is the number of games won divided by the number of games.
+	WinRate float64
+
+	// Moves and Guesses are the total moves and guesses of all games.
+	Moves, Guesses int
+
+	// Duration is the total time taken to play every game, which is longer
+	// than the time taken by the Run function as the games are played in
+	// parallel.
+	Duration time.Duration
+}
+
+// Run plays the number of games with the strategy, configured by the options
+// such as a minesweeper.Grid or a minesweeper.CustomDifficulty. The games are
+// played in parallel goroutines, one for every available CPU, and the ith game
+// is created with the minesweeper.WithSeed(seed+i) option, so running the same
+// strategy with the same seed and options always plays the same boards.
+//
+// A game is abandoned once the strategy fails to choose a move, chooses a move
+// that leaves the board unchanged or after four moves per cell. The error of the Play() method is
+// returned if the games cannot be started with the options.
+func Run(strategy Strategy, games int, seed int64, options ...minesweeper.Option) (Report, error) {
+	results := make([]Result, games)
+	if games > 0 {
+		game, _ := minesweeper.NewGame(options...)
+		if err := game.Play(); err != nil {
+			return Report{}, err
+		}
+	}
+
+	indexes := make(chan int)
+	var wait sync.WaitGroup
+	for worker := 0; worker < runtime.GOMAXPROCS(0); worker++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			for i := range indexes {
+				results[i] = play(strategy, seed+int64(i), options)
+			}
+		}()
+	}
+	for i := range results {
+		indexes <- i
+	}
+	close(indexes)
+	wait.Wait()
+
+	report := Report{Results: results}
+	for _, result := range results {
+		if result.Won() {
+			report.Wins++
+		}
+		report.Moves += result.Moves
+		report.Guesses += result.Guesses
+		report.Duration += result.Duration
+	}
+	if games > 0 {
+		report.WinRate = float64(report.Wins) / float64(games)
+	}
+	return report, nil
+}
+
+func play(strategy Strategy, seed int64, options []minesweeper.Option) Result {
+	start := time.Now()
+	result := Result{Seed: seed}
+
+	game, _ := minesweeper.NewGame(append(append([]minesweeper.Option(nil), options...), minesweeper.WithSeed(seed))...)
+	game.Play()
+
+	state := game.State()
+	view := game.View()
+	limit := movesPerCell * len(view) * len(view[0])
+	for state.Status == minesweeper.Ongoing && result.Moves < limit {
+		move, err := strategy.Move(view, state)
+		if err != nil {
+			result.Err = err
+			break
+		}
+		result.Moves++
+		if move.Guess {
+			result.Guesses++
+		}
+
+		switch move.Action {
+		case Visit:
+			_, err = game.Visit(move.X, move.Y)
+		case Flag:
+			err = game.Flag(move.X, move.Y)
+		case Chord:
+			_, err = game.Chord(move.X, move.Y)
+		}
+
+		previous := state
+		state = game.State()
+		if _, exploded := err.(*minesweeper.ExplodedError); err != nil && !exploded {
+			result.Err = err
+			break
+		}
+		if state.Moves == previous.Moves {
+			break
+		}
+		view = game.View()
+	}
+
+	result.Status = state.Status
+	result.Duration = time.Since(start)
+	return result
+}
@@ -0,0 +1,114 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package bot
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/rrborja/minesweeper"
+	"github.com/rrborja/minesweeper/solver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	report, err := Run(Solver, 20, 1, minesweeper.Beginner, minesweeper.WithSafeOpening())
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Results, 20)
+	assert.True(t, report.Wins > 0)
+	assert.Equal(t, float64(report.Wins)/20, report.WinRate)
+
+	var wins, moves, guesses int
+	for i, result := range report.Results {
+		assert.Equal(t, int64(1+i), result.Seed)
+		assert.NotEqual(t, minesweeper.Ongoing, result.Status)
+		assert.NoError(t, result.Err)
+		assert.True(t, result.Duration > 0)
+		assert.True(t, result.Guesses >= 1, "The first visit is always a guess")
+		if result.Won() {
+			wins++
+		}
+		moves += result.Moves
+		guesses += result.Guesses
+	}
+	assert.Equal(t, report.Wins, wins)
+	assert.Equal(t, report.Moves, moves)
+	assert.Equal(t, report.Guesses, guesses)
+}
+
+func TestRunIsReproducible(t *testing.T) {
+	first, _ := Run(Solver, 10, 7, minesweeper.Intermediate)
+	second, _ := Run(Solver, 10, 7, minesweeper.Intermediate)
+
+	for i := range first.Results {
+		first.Results[i].Duration, second.Results[i].Duration = 0, 0
+	}
+	assert.Equal(t, first.Results, second.Results)
+}
+
+func TestRunAbandonsUnchangedBoard(t *testing.T) {
+	var calls int32
+	report, err := Run(StrategyFunc(func(view minesweeper.BoardView, state minesweeper.GameState) (Move, error) {
+		atomic.AddInt32(&calls, 1)
+		return Move{Action: Chord, X: 0, Y: 0}, nil
+	}), 3, 0, minesweeper.Beginner)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), calls)
+	for _, result := range report.Results {
+		assert.Equal(t, minesweeper.Ongoing, result.Status)
+		assert.Equal(t, 1, result.Moves)
+	}
+	assert.Equal(t, 0.0, report.WinRate)
+}
+
+func TestRunLimitsMoves(t *testing.T) {
+	report, _ := Run(StrategyFunc(func(view minesweeper.BoardView, state minesweeper.GameState) (Move, error) {
+		return Move{Action: Flag, X: 0, Y: 0}, nil
+	}), 1, 0, minesweeper.Beginner)
+
+	assert.Equal(t, movesPerCell*81, report.Results[0].Moves)
+}
+
+func TestRunAbandonsOnError(t *testing.T) {
+	report, _ := Run(StrategyFunc(func(view minesweeper.BoardView, state minesweeper.GameState) (Move, error) {
+		return Move{Action: Visit, X: -1, Y: 0}, nil
+	}), 1, 0, minesweeper.Beginner)
+
+	assert.IsType(t, &minesweeper.OutOfBoundsError{}, report.Results[0].Err)
+}
+
+func TestRunInvalidOptions(t *testing.T) {
+	_, err := Run(Solver, 1, 0, minesweeper.CustomDifficulty{Width: 3, Height: 3, Mines: 9})
+
+	assert.IsType(t, &minesweeper.InvalidMineCountError{}, err)
+}
+
+func TestRunAbandonsOnStrategyError(t *testing.T) {
+	report, err := Run(Solver, 2, 0, minesweeper.Beginner, minesweeper.WithWrapAround())
+
+	assert.NoError(t, err)
+	for _, result := range report.Results {
+		assert.IsType(t, &solver.UnsupportedBoardError{}, result.Err)
+		assert.Equal(t, minesweeper.Ongoing, result.Status)
+		assert.Equal(t, 0, result.Moves)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import "github.com/rrborja/minesweeper/visited"
+
+// ChordResult is the outcome of chording a cell by the Chord(int, int) method.
+type ChordResult struct {
+	// Accepted reports whether the chorded cell is a visited warning number
+	// whose value equals the number of mines flagged on its neighboring cells.
+	Accepted bool
+
+	// Revealed are the cells revealed by the chord, including the cells
+	// automatically visited around a revealed cell with no warning number.
+	Revealed []Block
+
+	// Exploded are the mines hit by the chord.
+	Exploded []Block
+
+	// WrongFlags are the flagged neighboring cells that have no mine, or whose
+	// flag carries a different number than their mines, reported when the chord
+	// hits a mine.
+	WrongFlags []Block
+}
+
+func (game *game) Chord(x, y int) (ChordResult, error) {
+	return game.Chord3D(x, y, 0)
+}
+
+func (game *game) Chord3D(x, y, z int) (ChordResult, error) {
+	if err := game.validateGameEnvironment(); err != nil {
+		return ChordResult{}, err
+	}
+	if err := game.validateCoordinates(x, y, z); err != nil {
+		return ChordResult{}, err
+	}
+
+	// No cell is visited before the game starts, so the chord is not accepted
+	if game.Mutex == nil {
+		return ChordResult{}, nil
+	}
+
+	game.Lock()
+	defer game.Unlock()
+
+	if game.over() {
+		return ChordResult{}, &GameOverError{status: game.status()}
+	}
+
+	state := game.moveState()
+
+	result, err := game.chord(game.block(x, y, z))
+	if len(result.Revealed) > 0 || len(result.Exploded) > 0 {
+		blocks := result.Revealed
+		if err != nil {
+			blocks = game.revealedMines(result.Exploded...)
+		}
+		game.moves++
+		game.conclude()
+		game.publishVisit(x, y, z, true, blocks, err)
+		game.remember(x, y, z, state)
+	}
+	return result, err
+}
+
+// Visits all unflagged neighboring cells of the visited warning number when the
+// number of mines flagged on its neighboring cells equals its value. Unlike
+// visiting the cells one by one, every mine among them is hit.
+func (game *game) chord(block *Block) (result ChordResult, err error) {
+	if block.Node != Number || !block.visited {
+		return
+	}
+
+	var flaggedBlocks int
+	blocksToBeVisited := make([]*Block, 0)
+	wrongFlags := make([]Block, 0)
+
+	game.traverseAdjacentCells(block.X(), game.row(block), func(cell *Block) {
+		switch {
+		case cell.flagged:
+			flaggedBlocks += cell.flags
+			if cell.flags != cell.Mines() {
+				wrongFlags = append(wrongFlags, *cell)
+			}
+		case !cell.visited:
+			blocksToBeVisited = append(blocksToBeVisited, cell)
+		}
+	})
+
+	if flaggedBlocks != block.Value {
+		return
+	}
+	result.Accepted = true
+
+	for _, cell := range blocksToBeVisited {
+		blocks, visitErr := game.reveal(cell)
+		if visitErr != nil {
+			result.Exploded = append(result.Exploded, *cell)
+			if err == nil {
+				err = visitErr
+			}
+			continue
+		}
+		result.Revealed = append(result.Revealed, blocks...)
+	}
+
+	if len(result.Exploded) > 0 {
+		result.WrongFlags = wrongFlags
+	}
+	if len(result.Revealed) > 0 || len(result.Exploded) > 0 {
+		game.add(visited.Record{Position: *block, Action: visited.Chord})
+	}
+	return
+}
@@ -0,0 +1,132 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import (
+	"testing"
+
+	"github.com/rrborja/minesweeper/visited"
+	"github.com/stretchr/testify/assert"
+)
+
+// Creates a 5x5 board with mines at the given xy-coordinates and visits the
+// warning number at the center.
+func newChordGame(mines ...[2]int) Minesweeper {
+	minesweeper := newGameWithMines([]Option{Grid{5, 5}}, mines...)
+	minesweeper.Visit(2, 2)
+	return minesweeper
+}
+
+func TestChordRevealsNeighbors(t *testing.T) {
+	minesweeper := newChordGame([2]int{1, 1}, [2]int{4, 4})
+	minesweeper.Flag(1, 1)
+
+	result, err := minesweeper.Chord(2, 2)
+	assert.NoError(t, err)
+	assert.True(t, result.Accepted)
+	assert.Empty(t, result.Exploded)
+	assert.Empty(t, result.WrongFlags)
+
+	game := minesweeper.(*game)
+	game.traverseAdjacentCells(2, 2, func(cell *Block) {
+		if cell.Node != Bomb {
+			assert.True(t, cell.visited)
+		}
+	})
+	assert.Equal(t, 19, len(result.Revealed), "The flood fill reaches all safe cells but the chorded cell and the corner walled in by the flagged mine")
+}
+
+func TestChordNotAcceptedWhenFlagsMismatch(t *testing.T) {
+	minesweeper := newChordGame([2]int{1, 1}, [2]int{4, 4})
+
+	result, err := minesweeper.Chord(2, 2)
+	assert.NoError(t, err)
+	assert.False(t, result.Accepted)
+	assert.Empty(t, result.Revealed)
+	assert.Equal(t, 1, minesweeper.State().Moves, "A rejected chord is not a move")
+}
+
+func TestChordNotAcceptedOnHiddenCell(t *testing.T) {
+	minesweeper := newChordGame([2]int{1, 1}, [2]int{4, 4})
+
+	result, err := minesweeper.Chord(0, 4)
+	assert.NoError(t, err)
+	assert.False(t, result.Accepted)
+	assert.False(t, minesweeper.(*game).blocks[0][4].visited)
+}
+
+func TestChordNotAcceptedBeforePlay(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{5, 5})
+	minesweeper.SetDifficulty(Easy)
+
+	result, err := minesweeper.Chord3D(2, 2, 0)
+	assert.NoError(t, err)
+	assert.False(t, result.Accepted)
+	assert.Equal(t, NotStarted, minesweeper.State().Status)
+}
+
+func TestChordHitsAllMinesAtOnce(t *testing.T) {
+	minesweeper := newChordGame([2]int{1, 1}, [2]int{1, 2}, [2]int{4, 4})
+	minesweeper.Flag(3, 3)
+	minesweeper.Flag(3, 2)
+
+	result, err := minesweeper.Chord(2, 2)
+	assert.True(t, result.Accepted)
+	assert.Equal(t, &ExplodedError{x: 1, y: 1}, err)
+
+	game := minesweeper.(*game)
+	assert.Equal(t, []Block{game.blocks[1][1], game.blocks[1][2]}, result.Exploded)
+	assert.Equal(t, []Block{game.blocks[3][2], game.blocks[3][3]}, result.WrongFlags)
+	assert.True(t, game.blocks[1][3].visited, "Chord must not stop at the first mine")
+	assert.Equal(t, Lost, minesweeper.State().Status)
+}
+
+func TestChordIsRecordedAsDistinctAction(t *testing.T) {
+	minesweeper := newChordGame([2]int{1, 1}, [2]int{4, 4})
+	minesweeper.Flag(1, 1)
+	minesweeper.Chord(2, 2)
+
+	var story visited.StoryTeller = minesweeper.(*game)
+	assert.Equal(t, visited.Chord, story.LastAction().Action)
+	assert.Equal(t, 2, story.LastAction().X())
+	assert.Equal(t, 2, story.LastAction().Y())
+	assert.Equal(t, visited.Flag, story.History().History.Record.Action)
+}
+
+func TestChordOutOfBounds(t *testing.T) {
+	minesweeper := newChordGame([2]int{1, 1})
+
+	_, err := minesweeper.Chord(5, 0)
+	assert.IsType(t, new(OutOfBoundsError), err)
+}
+
+func TestVisitChordsVisitedNumber(t *testing.T) {
+	minesweeper := newChordGame([2]int{1, 1}, [2]int{1, 2}, [2]int{4, 4})
+	minesweeper.Flag(3, 3)
+	minesweeper.Flag(3, 2)
+
+	blocks, err := minesweeper.Visit(2, 2)
+	assert.Equal(t, &ExplodedError{x: 1, y: 1}, err)
+
+	game := minesweeper.(*game)
+	assert.Equal(t, 3, len(blocks))
+	assert.Equal(t, game.blocks[1][1], blocks[0])
+	assert.Equal(t, game.blocks[1][2], blocks[1])
+}
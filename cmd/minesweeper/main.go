@@ -0,0 +1,132 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+// Command minesweeper plays minesweeper in the terminal.
+//
+// Usage:
+//
+//	minesweeper [-difficulty beginner|intermediate|expert] [-width n] [-height n] [-mines n] [-seed n]
+//
+// Move the cursor with the arrow keys, WASD or HJKL. Press space or enter to
+// visit a cell, f to flag it, c to chord it, u to undo the last move, n to start
+// a new game and q to quit.
+//
+// The terminal is put into raw mode by the stty command, which is available on
+// Linux, macOS and other Unix-like systems.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rrborja/minesweeper"
+)
+
+var difficulties = map[string]minesweeper.CustomDifficulty{
+	"beginner":     minesweeper.Beginner,
+	"intermediate": minesweeper.Intermediate,
+	"expert":       minesweeper.Expert,
+}
+
+func main() {
+	difficulty := flag.String("difficulty", "beginner", "the preset of the board: beginner, intermediate or expert")
+	width := flag.Int("width", 0, "the number of columns of the board, overriding the difficulty")
+	height := flag.Int("height", 0, "the number of rows of the board, overriding the difficulty")
+	mines := flag.Int("mines", 0, "the number of mines, overriding the difficulty")
+	seed := flag.Int64("seed", 0, "the seed of the first game, to replay a board")
+	flag.Parse()
+
+	preset, err := configure(*difficulty, *width, *height, *mines)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	session := &session{preset: preset}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			session.seed = seed
+		}
+	})
+	if err := session.start(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := play(session); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Returns the preset of the difficulty with its size and number of mines
+// overridden by the non-zero arguments
+func configure(difficulty string, width, height, mines int) (minesweeper.CustomDifficulty, error) {
+	preset, ok := difficulties[strings.ToLower(difficulty)]
+	if !ok {
+		return preset, fmt.Errorf("unknown difficulty %q, expected beginner, intermediate or expert", difficulty)
+	}
+	if width > 0 {
+		preset.Width = width
+	}
+	if height > 0 {
+		preset.Height = height
+	}
+	if mines > 0 {
+		preset.Mines = mines
+	}
+	return preset, nil
+}
+
+func play(session *session) error {
+	restore, err := rawMode()
+	if err != nil {
+		return fmt.Errorf("cannot read the keyboard: %v", err)
+	}
+	defer restore()
+
+	fmt.Print(hideCursor)
+	defer fmt.Print(showCursor, "\r\n")
+
+	keys := make(chan []byte)
+	go read(os.Stdin, keys)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	session.draw(os.Stdout)
+	for {
+		select {
+		case input, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			for _, key := range decode(input) {
+				if key == keyQuit {
+					return nil
+				}
+				session.press(key)
+			}
+		case <-ticker.C:
+		}
+		session.draw(os.Stdout)
+	}
+}
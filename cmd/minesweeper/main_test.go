@@ -0,0 +1,40 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package main
+
+import (
+	"testing"
+
+	"github.com/rrborja/minesweeper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigure(t *testing.T) {
+	preset, err := configure("Expert", 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, minesweeper.Expert, preset)
+
+	preset, err = configure("beginner", 12, 0, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, minesweeper.CustomDifficulty{Width: 12, Height: 9, Mines: 20}, preset)
+
+	_, err = configure("impossible", 0, 0, 0)
+	assert.EqualError(t, err, `unknown difficulty "impossible", expected beginner, intermediate or expert`)
+}
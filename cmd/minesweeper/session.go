@@ -0,0 +1,190 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rrborja/minesweeper"
+)
+
+// numberColors are the classic colors of the warning numbers
+var numberColors = [...]string{
+	1: "\x1b[94m",
+	2: "\x1b[32m",
+	3: "\x1b[91m",
+	4: "\x1b[34m",
+	5: "\x1b[31m",
+	6: "\x1b[36m",
+	7: "\x1b[35m",
+	8: "\x1b[90m",
+}
+
+// session is the game being played in the terminal with the cursor at the
+// xy-coordinates
+type session struct {
+	game   minesweeper.Minesweeper
+	preset minesweeper.CustomDifficulty
+
+	// seed is the seed of the next game, which is random when nil
+	seed *int64
+
+	x, y    int
+	message string
+}
+
+func (session *session) start() error {
+	options := []minesweeper.Option{session.preset, minesweeper.WithSafeOpening()}
+	if session.seed != nil {
+		options = append(options, minesweeper.WithSeed(*session.seed))
+		session.seed = nil
+	}
+
+	game, _ := minesweeper.NewGame(options...)
+	if err := game.Play(); err != nil {
+		return err
+	}
+	session.game = game
+	session.x, session.y = session.preset.Width/2, session.preset.Height/2
+	session.message = ""
+	return nil
+}
+
+func (session *session) press(key key) {
+	switch key {
+	case keyUp:
+		session.y = (session.y + session.preset.Height - 1) % session.preset.Height
+	case keyDown:
+		session.y = (session.y + 1) % session.preset.Height
+	case keyLeft:
+		session.x = (session.x + session.preset.Width - 1) % session.preset.Width
+	case keyRight:
+		session.x = (session.x + 1) % session.preset.Width
+	case keyNew:
+		session.start()
+		return
+	}
+
+	if status := session.game.State().Status; status == minesweeper.Won || status == minesweeper.Lost {
+		if key == keyUndo {
+			session.undo()
+		}
+		return
+	}
+
+	switch key {
+	case keyVisit:
+		session.visit()
+	case keyFlag:
+		session.game.Flag(session.x, session.y)
+	case keyChord:
+		session.chord()
+	case keyUndo:
+		session.undo()
+	}
+}
+
+func (session *session) visit() {
+	session.message = ""
+	if _, err := session.game.Visit(session.x, session.y); err != nil {
+		session.message = err.Error()
+	}
+}
+
+func (session *session) chord() {
+	session.message = ""
+	result, err := session.game.Chord(session.x, session.y)
+	switch {
+	case err != nil:
+		session.message = err.Error()
+	case !result.Accepted:
+		session.message = "Flag as many neighboring cells as the warning number to chord."
+	}
+}
+
+func (session *session) undo() {
+	session.message = ""
+	if err := session.game.Undo(); err != nil {
+		session.message = err.Error()
+	}
+}
+
+// Draws the board with the mine counter and the timer above it and the status
+// of the game below it. The lines end with a carriage return as the terminal is
+// in raw mode.
+func (session *session) draw(writer io.Writer) {
+	state := session.game.State()
+	view := session.game.View()
+
+	var frame strings.Builder
+	frame.WriteString(clearScreen)
+	fmt.Fprintf(&frame, "Mines: %3d   Time: %3d   Seed: %v\r\n\r\n", state.MinesRemaining, int(state.Elapsed.Seconds()), session.game.Seed())
+	for y := 0; y < session.preset.Height; y++ {
+		for x := 0; x < session.preset.Width; x++ {
+			symbol := cell(view[x][y])
+			if x == session.x && y == session.y {
+				symbol = reverse + strings.TrimSuffix(symbol, reset) + reset
+			}
+			frame.WriteString(symbol + " ")
+		}
+		frame.WriteString("\r\n")
+	}
+	frame.WriteString("\r\n")
+
+	switch state.Status {
+	case minesweeper.Won:
+		frame.WriteString("You won the game! Press n to play again or q to quit.")
+	case minesweeper.Lost:
+		frame.WriteString("Game over! Press u to undo, n to play again or q to quit.")
+	default:
+		frame.WriteString("Arrows: move   Space: visit   F: flag   C: chord   U: undo   N: new game   Q: quit")
+	}
+	if session.message != "" {
+		frame.WriteString("\r\n" + session.message)
+	}
+	io.WriteString(writer, frame.String())
+}
+
+// Returns the symbol of the cell as observed by the player
+func cell(cell minesweeper.Cell) string {
+	switch cell.State {
+	case minesweeper.Flagged:
+		return "\x1b[91mF" + reset
+	case minesweeper.QuestionMarked:
+		return "?"
+	case minesweeper.RevealedBlank:
+		return " "
+	case minesweeper.RevealedNumber:
+		if cell.Value < len(numberColors) {
+			return fmt.Sprintf("%v%v%v", numberColors[cell.Value], cell.Value, reset)
+		}
+		return fmt.Sprint(cell.Value)
+	case minesweeper.ExplodedMine:
+		return "\x1b[41m*" + reset
+	case minesweeper.RevealedMine:
+		return "*"
+	case minesweeper.WrongFlag:
+		return "\x1b[91mX" + reset
+	default:
+		return "."
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rrborja/minesweeper"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSession(seed int64) *session {
+	session := &session{preset: minesweeper.Beginner, seed: &seed}
+	session.start()
+	return session
+}
+
+func TestSessionCursorWrapsAround(t *testing.T) {
+	session := newSession(5)
+	assert.Equal(t, 4, session.x)
+	assert.Equal(t, 4, session.y)
+
+	for i := 0; i < 5; i++ {
+		session.press(keyLeft)
+		session.press(keyUp)
+	}
+	assert.Equal(t, 8, session.x)
+	assert.Equal(t, 8, session.y)
+
+	session.press(keyRight)
+	session.press(keyDown)
+	assert.Equal(t, 0, session.x)
+	assert.Equal(t, 0, session.y)
+}
+
+func TestSessionMoves(t *testing.T) {
+	session := newSession(5)
+	assert.Equal(t, int64(5), session.game.Seed())
+
+	session.press(keyVisit)
+	assert.Equal(t, minesweeper.RevealedBlank, session.game.View()[4][4].State)
+
+	session.x, session.y = 8, 8
+	session.press(keyFlag)
+	assert.Equal(t, minesweeper.Flagged, session.game.View()[8][8].State)
+
+	session.press(keyUndo)
+	assert.Equal(t, minesweeper.Hidden, session.game.View()[8][8].State)
+
+	session.x, session.y = 4, 4
+	session.press(keyChord)
+	assert.Equal(t, "Flag as many neighboring cells as the warning number to chord.", session.message)
+
+	session.press(keyNew)
+	assert.NotEqual(t, int64(5), session.game.Seed(), "Only the first game is created with the seed")
+	assert.Equal(t, minesweeper.Ongoing, session.game.State().Status)
+	assert.Equal(t, minesweeper.Hidden, session.game.View()[4][4].State)
+}
+
+func TestSessionDraw(t *testing.T) {
+	session := newSession(5)
+	session.press(keyVisit)
+
+	var output bytes.Buffer
+	session.draw(&output)
+	lines := strings.Split(output.String(), "\r\n")
+
+	assert.Equal(t, clearScreen+"Mines:  10   Time:   0   Seed: 5", lines[0])
+	assert.Len(t, lines, 13)
+	assert.Equal(t, ". . "+numberColors[1]+"1"+reset+"   "+reverse+" "+reset+" "+numberColors[1]+"1"+reset+" "+numberColors[2]+"2"+reset+" . . ", lines[6])
+	assert.Contains(t, lines[12], "Space: visit")
+}
+
+func TestCell(t *testing.T) {
+	assert.Equal(t, ".", cell(minesweeper.Cell{State: minesweeper.Hidden}))
+	assert.Equal(t, " ", cell(minesweeper.Cell{State: minesweeper.RevealedBlank}))
+	assert.Equal(t, "\x1b[32m2"+reset, cell(minesweeper.Cell{State: minesweeper.RevealedNumber, Value: 2}))
+	assert.Equal(t, "12", cell(minesweeper.Cell{State: minesweeper.RevealedNumber, Value: 12}))
+	assert.Equal(t, "*", cell(minesweeper.Cell{State: minesweeper.RevealedMine}))
+	assert.Equal(t, "\x1b[41m*"+reset, cell(minesweeper.Cell{State: minesweeper.ExplodedMine}))
+}
@@ -0,0 +1,128 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package main
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+	reverse     = "\x1b[7m"
+	reset       = "\x1b[0m"
+)
+
+// key is a key press understood by the game
+type key uint8
+
+const (
+	keyNone key = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyVisit
+	keyFlag
+	keyChord
+	keyUndo
+	keyNew
+	keyQuit
+)
+
+var keys = map[byte]key{
+	'w': keyUp, 'k': keyUp,
+	's': keyDown, 'j': keyDown,
+	'a': keyLeft, 'h': keyLeft,
+	'd': keyRight, 'l': keyRight,
+	' ': keyVisit, '\r': keyVisit, '\n': keyVisit,
+	'f': keyFlag,
+	'c': keyChord,
+	'u': keyUndo,
+	'n': keyNew,
+	'q': keyQuit, 3: keyQuit,
+}
+
+var arrows = map[byte]key{'A': keyUp, 'B': keyDown, 'C': keyRight, 'D': keyLeft}
+
+// Puts the terminal into raw mode so every key is read as soon as it is pressed
+// without being echoed, returning the function that restores the terminal
+func rawMode() (restore func(), err error) {
+	state, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty("raw", "-echo"); err != nil {
+		return nil, err
+	}
+	return func() {
+		stty(strings.TrimSpace(state))
+	}, nil
+}
+
+func stty(args ...string) (string, error) {
+	command := exec.Command("stty", args...)
+	command.Stdin = os.Stdin
+	output, err := command.Output()
+	return string(output), err
+}
+
+// Sends every input read from the reader to the channel until the reader is
+// exhausted
+func read(reader io.Reader, inputs chan<- []byte) {
+	defer close(inputs)
+	buffer := make([]byte, 64)
+	for {
+		n, err := reader.Read(buffer)
+		if n > 0 {
+			inputs <- append([]byte(nil), buffer[:n]...)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Decodes the keys of the input, where an arrow key is the escape sequence of
+// the escape character, the left bracket and a letter
+func decode(input []byte) []key {
+	decoded := make([]key, 0, len(input))
+	for i := 0; i < len(input); i++ {
+		if input[i] == 0x1b && i+2 < len(input) && input[i+1] == '[' {
+			if arrow, ok := arrows[input[i+2]]; ok {
+				decoded = append(decoded, arrow)
+			}
+			i += 2
+			continue
+		}
+		character := input[i]
+		if character >= 'A' && character <= 'Z' {
+			character += 'a' - 'A'
+		}
+		if key, ok := keys[character]; ok {
+			decoded = append(decoded, key)
+		}
+	}
+	return decoded
+}
@@ -0,0 +1,43 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	assert.Equal(t, []key{keyUp, keyDown, keyRight, keyLeft}, decode([]byte("\x1b[A\x1b[B\x1b[C\x1b[D")))
+	assert.Equal(t, []key{keyUp, keyLeft, keyDown, keyRight, keyUp, keyLeft, keyDown, keyRight}, decode([]byte("wasdkhjl")))
+	assert.Equal(t, []key{keyVisit, keyVisit, keyFlag, keyFlag, keyChord, keyUndo, keyNew, keyQuit, keyQuit}, decode([]byte(" \rfFcunq\x03")))
+	assert.Empty(t, decode([]byte("xyz\x1b[Z")))
+}
+
+func TestRead(t *testing.T) {
+	inputs := make(chan []byte, 1)
+	go read(bytes.NewBufferString("f"), inputs)
+
+	assert.Equal(t, []byte("f"), <-inputs)
+	_, ok := <-inputs
+	assert.False(t, ok)
+}
@@ -2,10 +2,10 @@ package minesweeper
 
 import (
 	"container/list"
-	"crypto/rand"
-	"encoding/binary"
 	"fmt"
+	mathrand "math/rand"
 	"sync"
+	"time"
 
 	"github.com/rrborja/minesweeper/visited"
 )
@@ -23,6 +23,11 @@ type board struct {
 	*Grid
 	blocks
 	difficultyMultiplier float32
+	mines                int
+	topology             Topology
+	wrapAround           bool
+	depth                int
+	maxMines             int
 }
 
 type game struct {
@@ -31,6 +36,16 @@ type game struct {
 	Difficulty
 	recordedActions
 	*sync.Mutex
+	publisher
+	timer
+	tally
+	moves  int
+	seed   int64
+	random *mathrand.Rand
+	firstVisitSafety
+	minesPlaced   bool
+	ranked        bool
+	questionMarks bool
 }
 
 var singleton Minesweeper
@@ -44,82 +59,160 @@ func (game *game) SetGrid(width, height int) error {
 	return nil
 }
 
-func (game *game) Flag(x, y int) {
-	blockPtr := &game.blocks[x][y]
+func (game *game) Flag(x, y int) error {
+	return game.Flag3D(x, y, 0)
+}
+
+func (game *game) Flag3D(x, y, z int) error {
+	if game.Grid == nil {
+		return new(UnspecifiedGridError)
+	}
+	if err := game.validateCoordinates(x, y, z); err != nil {
+		return err
+	}
+
+	if game.Mutex != nil {
+		game.Lock()
+		defer game.Unlock()
+	}
+
+	if game.over() {
+		return &GameOverError{status: game.status()}
+	}
+
+	blockPtr := game.block(x, y, z)
 	if !blockPtr.visited {
-		blockPtr.flagged = !blockPtr.flagged
+		state := game.moveState()
+		game.touch(blockPtr)
+		kind, action := game.mark(blockPtr)
+		game.moves++
+		game.add(visited.Record{Position: *blockPtr, Action: action})
+		game.publish(GameEvent{Kind: kind, X: x, Y: y, Z: z})
+		game.remember(x, y, z, state)
+	}
+	return nil
+}
+
+// Cycles the mark of the block from unmarked to flagged, then from flagged to
+// question-marked if the game is created with the WithQuestionMarks() option,
+// then back to unmarked. In a game created with the WithMaxMinesPerCell(int)
+// option, the number carried by the flag is raised up to the maximum number
+// of mines of a cell before the flag is removed.
+func (game *game) mark(block *Block) (EventKind, visited.Action) {
+	switch {
+	case block.flagged && block.flags < game.maxMinesPerCell():
+		block.flags++
+		game.flags++
+		return CellFlagged, visited.Flag
+	case block.flagged && game.questionMarks:
+		block.flagged, block.questioned = false, true
+		game.flags -= block.flags
+		block.flags = 0
+		return CellQuestionMarked, visited.QuestionMark
+	case block.flagged:
+		block.flagged = false
+		game.flags -= block.flags
+		block.flags = 0
+		return CellUnflagged, visited.Unmark
+	case block.questioned:
+		block.questioned = false
+		return CellUnflagged, visited.Unmark
+	default:
+		block.flagged = true
+		block.flags = 1
+		game.flags++
+		return CellFlagged, visited.Flag
 	}
 }
 
 func (game *game) Visit(x, y int) ([]Block, error) {
-	game.validateGameEnvironment()
+	return game.Visit3D(x, y, 0)
+}
+
+func (game *game) Visit3D(x, y, z int) ([]Block, error) {
+	if err := game.validateGameEnvironment(); err != nil {
+		return nil, err
+	}
+	if err := game.validateCoordinates(x, y, z); err != nil {
+		return nil, err
+	}
+	if game.Mutex == nil {
+		return nil, new(GameNotStartedError)
+	}
 
 	game.Lock()
 	defer game.Unlock()
 
-	block := &game.blocks[x][y]
-	if block.Node == Number && block.visited {
-		countedFlaggedBlock := 0
-		resultedBlocks := make([]Block, 0)
-		blocksToBeVisited := make([]*Block, 0)
-
-		game.traverseAdjacentCells(x, y, func(cell *Block) {
-			if cell.flagged {
-				countedFlaggedBlock++
-			} else {
-				blocksToBeVisited = append(blocksToBeVisited, cell)
-			}
-		})
+	if game.over() {
+		return nil, &GameOverError{status: game.status()}
+	}
 
-		if countedFlaggedBlock == block.Value {
-			for _, block := range blocksToBeVisited {
-				blocks, err := game.visit(block.X(), block.Y())
-				if err != nil {
-					return blocks, err
-				}
-				resultedBlocks = append(resultedBlocks, blocks...)
-			}
+	block := game.block(x, y, z)
+	if !game.minesPlaced {
+		if err := game.placeMinesOnFirstVisit(block); err != nil {
+			return nil, err
 		}
-		return resultedBlocks, nil
 	}
-	return game.visit(x, y)
+
+	chording := block.Node == Number && block.visited
+	state := game.moveState()
+
+	blocks, err := game.visitOrChord(block)
+	if len(blocks) > 0 {
+		game.moves++
+		game.conclude()
+		game.publishVisit(x, y, z, chording, blocks, err)
+		game.remember(x, y, z, state)
+	}
+	return blocks, err
 }
 
-func (game *game) visit(x, y int) ([]Block, error) {
-	block := &game.blocks[x][y]
+func (game *game) visitOrChord(block *Block) ([]Block, error) {
+	if block.Node == Number && block.visited {
+		result, err := game.chord(block)
+		if err != nil {
+			return game.revealedMines(result.Exploded...), err
+		}
+		return append(make([]Block, 0, len(result.Revealed)), result.Revealed...), nil
+	}
+	return game.visit(block)
+}
 
+func (game *game) visit(block *Block) ([]Block, error) {
+	blocks, err := game.reveal(block)
+	if len(blocks) > 0 {
+		var action visited.Action
+		switch blocks[0].Node {
+		case Number:
+			action = visited.Number
+		case Bomb:
+			action = visited.Bomb
+		case Unknown:
+			action = visited.Unknown
+		}
+		game.add(visited.Record{Position: blocks[0], Action: action})
+	}
+	return blocks, err
+}
+
+// Reveals the block without recording the player's action. The revealed blocks
+// are returned with the block as the first element.
+func (game *game) reveal(block *Block) ([]Block, error) {
 	if !block.flagged && !block.visited {
-		block.visited = true
-		defer func() {
-			go game.validateSolution()
-		}()
+		game.touch(block)
+		block.visited, block.questioned = true, false
 		switch block.Node {
 		case Number:
-			defer game.add(visited.Record{
-				Position: *block, Action: visited.Number})
+			game.revealed++
 			return []Block{*block}, nil
 		case Bomb:
-			defer game.add(visited.Record{
-				Position: *block, Action: visited.Bomb})
-
-			bombLocations := make([]Block, 0, game.totalBombs()-1)
-
-			for _, bombLocation := range game.BombLocations() {
-				if bombLocation != *block {
-					bombLocations = append(bombLocations, bombLocation.(Block))
-				}
-			}
-
-			bombLocations = append([]Block{*block}, bombLocations...)
-
-			return bombLocations, &ExplodedError{x: x, y: y}
+			game.exploded = true
+			return game.revealedMines(*block), &ExplodedError{x: block.X(), y: block.Y(), z: block.layer}
 		case Unknown:
-			defer game.add(visited.Record{
-				Position: *block, Action: visited.Unknown})
 			block.visited = false //to avoid infinite recursion, first is to set the base case
 
 			visitedList := list.New()
-			autoRevealUnmarkedBlock(game, visitedList, x, y)
+			autoRevealUnmarkedBlock(game, visitedList, block.X(), game.row(block))
 
 			visitedBlocks := make([]Block, visitedList.Len())
 
@@ -135,6 +228,24 @@ func (game *game) visit(x, y int) ([]Block, error) {
 	return nil, nil
 }
 
+// Returns the location of all mines with the visited mines as the first elements
+func (game *game) revealedMines(visitedMines ...Block) []Block {
+	bombLocations := make([]Block, 0, game.totalBombs())
+	bombLocations = append(bombLocations, visitedMines...)
+
+nextBomb:
+	for _, bombLocation := range game.BombLocations() {
+		for _, visitedMine := range visitedMines {
+			if bombLocation.(Block).location == visitedMine.location && bombLocation.Z() == visitedMine.Z() {
+				continue nextBomb
+			}
+		}
+		bombLocations = append(bombLocations, bombLocation.(Block))
+	}
+
+	return bombLocations
+}
+
 func (game *game) SetDifficulty(difficulty Difficulty) error {
 	if game.Mutex != nil {
 		return new(GameAlreadyStartedError)
@@ -153,6 +264,17 @@ func (game *game) SetDifficulty(difficulty Difficulty) error {
 	return nil
 }
 
+func (game *game) SetMineCount(mines int) error {
+	if game.Mutex != nil {
+		return new(GameAlreadyStartedError)
+	}
+
+	game.Difficulty = custom
+	game.mines = mines
+
+	return nil
+}
+
 func (game *game) Play() error {
 	if game.Difficulty == notSet {
 		return new(UnspecifiedDifficultyError)
@@ -160,17 +282,68 @@ func (game *game) Play() error {
 	if game.Grid == nil {
 		return new(UnspecifiedGridError)
 	}
+	if err := game.validateBoard(); err != nil {
+		return err
+	}
 
 	if game.Mutex != nil {
 		return new(GameAlreadyStartedError)
 	}
 	game.Mutex = new(sync.Mutex)
+	game.startTime = time.Now()
+	game.publish(GameEvent{Kind: GameStarted})
+
+	if game.firstVisitSafety == unsafeFirstVisit {
+		game.placeMines()
+	}
+	return nil
+}
 
-	createBombs(game)
+// Returns the error of a board that cannot be played, either because its mines
+// do not fit or because its options cannot be combined
+func (game *game) validateBoard() error {
+	if mines := game.totalBombs(); mines < 0 || mines > (game.area()-1)*game.maxMinesPerCell() {
+		return &InvalidMineCountError{mines: mines, grid: *game.Grid, depth: game.depth, maxMinesPerCell: game.maxMines}
+	}
+	if game.wrapAround && game.topology == Hexagonal && game.Width%2 != 0 {
+		return &OddWrapAroundWidthError{grid: *game.Grid}
+	}
+	if game.firstVisitSafety == noGuessOpening && game.maxMinesPerCell() > 1 {
+		return &NoGuessMultiMineError{maxMinesPerCell: game.maxMines}
+	}
+	return nil
+}
+
+func (game *game) placeMines(safeBlocks ...*Block) {
+	createBombs(game, safeBlocks...)
 	tallyHints(game)
+	game.minesPlaced = true
+}
+
+func (game *game) placeMinesOnFirstVisit(block *Block) error {
+	if game.firstVisitSafety == noGuessOpening {
+		return game.placeSolvableMines(block)
+	}
+	game.placeMines(game.safeBlocks(block)...)
 	return nil
 }
 
+// Returns the blocks that must be free of mines when the first visited cell
+// is the block. The neighbors of the cell are excluded when there is no room
+// to place all the mines around the opening.
+func (game *game) safeBlocks(block *Block) []*Block {
+	safeBlocks := []*Block{block}
+	if game.firstVisitSafety == safeOpening || game.firstVisitSafety == noGuessOpening {
+		game.traverseAdjacentCells(block.X(), game.row(block), func(cell *Block) {
+			safeBlocks = append(safeBlocks, cell)
+		})
+		if (game.area()-len(safeBlocks))*game.maxMinesPerCell() < game.totalBombs() {
+			safeBlocks = safeBlocks[:1]
+		}
+	}
+	return safeBlocks
+}
+
 // X returns the X coordinate of the block in the minesweeper grid
 func (block Block) X() int {
 	return block.location.x
@@ -181,6 +354,12 @@ func (block Block) Y() int {
 	return block.location.y
 }
 
+// Z returns the layer of the block in the board created with the Grid3D option.
+// The layer of every block of a flat board is zero.
+func (block Block) Z() int {
+	return block.layer
+}
+
 // Shifts to the right
 func shiftPosition(grid *Grid, x, y int) (_x, _y int) {
 	width := grid.Width
@@ -197,22 +376,32 @@ func shiftPosition(grid *Grid, x, y int) (_x, _y int) {
 	return
 }
 
-func createBombs(game *game) {
-	area := int(game.Width * game.Height)
-	for i := 0; i < int(float32(area)*game.difficultyMultiplier); i++ {
+func createBombs(game *game, safeBlocks ...*Block) {
+	isSafe := func(block *Block) bool {
+		for _, safeBlock := range safeBlocks {
+			if safeBlock == block {
+				return true
+			}
+		}
+		return false
+	}
+
+	area := game.area()
+	layers := &Grid{game.Width, game.Height * game.layers()}
+	for i := 0; i < game.totalBombs(); i++ {
 		for {
-			randomPos := randomNumber(area)
+			randomPos := game.randomNumber(area)
 
 			x, y := randomPos%game.Width, randomPos/game.Width
 
 			countLimit := 0
-			for game.board.blocks[x][y].Node != Unknown {
-				x, y = shiftPosition(game.Grid, x, y)
+			for game.board.blocks[x][y].Mines() >= game.maxMinesPerCell() || isSafe(&game.blocks[x][y]) {
+				x, y = shiftPosition(layers, x, y)
 				countLimit++
 			}
 
 			if countLimit <= consecutiveRandomLimit {
-				game.blocks[x][y].Node = Bomb
+				game.blocks[x][y].addMine()
 				break
 			}
 		}
@@ -221,10 +410,10 @@ func createBombs(game *game) {
 
 func tallyHints(game *game) {
 	game.iterateBlocksWhen(Bomb, func(block *Block) {
-		game.traverseAdjacentCells(block.X(), block.Y(), func(cell *Block) {
+		game.traverseAdjacentCells(block.X(), game.row(block), func(cell *Block) {
 			if cell.Node != Bomb {
 				cell.Node = Number
-				cell.Value++
+				cell.Value += block.Mines()
 			}
 		})
 	})
@@ -233,14 +422,15 @@ func tallyHints(game *game) {
 func createBoard(game *game) {
 	game.blocks = make([][]Block, game.Width)
 	for x := range game.blocks {
-		game.blocks[x] = make([]Block, game.Height)
+		game.blocks[x] = make([]Block, game.Height*game.layers())
 	}
 	for x, row := range game.blocks {
 		for y := range row {
 			block := &game.blocks[x][y]
 			block.Value = 0
 			block.Node = Unknown
-			block.location = struct{ x, y int }{x: x, y: y}
+			block.location = struct{ x, y int }{x: x, y: y % game.Height}
+			block.layer = y / game.Height
 		}
 	}
 }
@@ -249,84 +439,91 @@ func autoRevealUnmarkedBlock(game *game, visitedBlocks *list.List, x, y int) {
 	blocks := game.blocks
 
 	game.withinBounds(x, y, func() {
-		if blocks[x][y].visited {
+		if blocks[x][y].visited || blocks[x][y].flagged {
 			return
 		}
 		switch blocks[x][y].Node {
 		case Unknown:
-			blocks[x][y].visited = true
+			game.touch(&blocks[x][y])
+			blocks[x][y].visited, blocks[x][y].questioned = true, false
+			game.revealed++
 
 			visitedBlocks.PushBack(blocks[x][y])
 
 			game.traverseAdjacentCells(x, y, func(cell *Block) {
-				autoRevealUnmarkedBlock(game, visitedBlocks, cell.X(), cell.Y())
+				autoRevealUnmarkedBlock(game, visitedBlocks, cell.X(), game.row(cell))
 			})
 		case Number:
-			blocks[x][y].visited = true
+			game.touch(&blocks[x][y])
+			blocks[x][y].visited, blocks[x][y].questioned = true, false
+			game.revealed++
 
 			visitedBlocks.PushBack(blocks[x][y])
 		}
 	})
 }
 
-func (game *game) validateSolution() {
-	defer skipIterate()
-
-	var visitTally int
-	game.iterateVisitedBlocks(func(block *Block) {
-		switch block.Node {
-		case Bomb:
-			game.Event <- Lose
-			panic("")
-		default:
-			visitTally++
-		}
-	})
-	if visitTally == game.totalNonBombs() {
-		game.Event <- Win
-	}
-}
-
-func (game *game) validateGameEnvironment() {
+func (game *game) validateGameEnvironment() error {
 	if game.Grid == nil {
-		panic(UnspecifiedGridError{})
+		return new(UnspecifiedGridError)
 	}
 	if game.Difficulty == notSet {
-		panic(UnspecifiedDifficultyError{})
+		return new(UnspecifiedDifficultyError)
 	}
+	return nil
+}
+
+func (game *game) validateCoordinates(x, y, z int) error {
+	if x < 0 || y < 0 || z < 0 || x >= game.Width || y >= game.Height || z >= game.layers() {
+		return &OutOfBoundsError{x: x, y: y, z: z, grid: *game.Grid, depth: game.depth}
+	}
+	return nil
 }
 
 func (game *game) traverseAdjacentCells(x, y int, do func(*Block)) {
-	game.recursivelyTraverseAdjacentCells(x-1, y-1, do)
-	game.recursivelyTraverseAdjacentCells(x, y-1, do)
-	game.recursivelyTraverseAdjacentCells(x+1, y-1, do)
-	game.recursivelyTraverseAdjacentCells(x-1, y, do)
-	game.recursivelyTraverseAdjacentCells(x+1, y, do)
-	game.recursivelyTraverseAdjacentCells(x-1, y+1, do)
-	game.recursivelyTraverseAdjacentCells(x, y+1, do)
-	game.recursivelyTraverseAdjacentCells(x+1, y+1, do)
+	for _, neighbor := range game.neighbors(x, y) {
+		x, y := neighbor[0], neighbor[1]
+		game.withinBounds(x, y, func() {
+			do(&game.blocks[x][y])
+		})
+	}
 }
 
-func (game *game) recursivelyTraverseAdjacentCells(x, y int, do func(*Block)) {
-	game.withinBounds(x, y, func() {
-		do(&game.blocks[x][y])
-	})
+// The layers of the board are stacked along the y-coordinate of the game.blocks
+// array, so the block at the xyz-coordinates is at the row z*Height+y.
+func (game *game) block(x, y, z int) *Block {
+	return &game.blocks[x][z*game.Height+y]
+}
+
+func (game *game) row(block *Block) int {
+	return block.layer*game.Height + block.location.y
+}
+
+func (game *game) layers() int {
+	if game.depth < 1 {
+		return 1
+	}
+	return game.depth
 }
 
+// The y-coordinate of the blocks within bounds continues across the layers of
+// the board, as in the game.blocks array.
 func (game *game) withinBounds(x, y int, do func()) {
 	width := game.Width
-	height := game.Height
+	height := game.Height * game.layers()
 	if x >= 0 && y >= 0 && x < width && y < height {
 		do()
 	}
 }
 
+// Iterates all blocks until the do function returns false, in which case
+// this method also returns false.
 func (game *game) iterateBlocks(do func(*Block) bool) bool {
-	defer skipIterate()
-
 	for x := 0; x < game.Width; x++ {
-		for y := 0; y < game.Height; y++ {
-			do(&game.blocks[x][y])
+		for y := 0; y < len(game.blocks[x]); y++ {
+			if !do(&game.blocks[x][y]) {
+				return false
+			}
 		}
 	}
 	return true
@@ -334,8 +531,6 @@ func (game *game) iterateBlocks(do func(*Block) bool) bool {
 
 func (game *game) iterateBlocksWhen(condition Node, do func(*Block)) bool {
 	return game.iterateBlocks(func(block *Block) bool {
-		defer skipIterate()
-
 		if block.Node&condition == condition {
 			do(block)
 		}
@@ -345,8 +540,6 @@ func (game *game) iterateBlocksWhen(condition Node, do func(*Block)) bool {
 
 func (game *game) iterateVisitedBlocks(do func(*Block)) bool {
 	return game.iterateBlocks(func(block *Block) bool {
-		defer skipIterate()
-
 		if block.visited {
 			do(block)
 		}
@@ -354,11 +547,6 @@ func (game *game) iterateVisitedBlocks(do func(*Block)) bool {
 	})
 }
 
-func skipIterate() bool {
-	recover()
-	return false
-}
-
 func (game *game) area() int {
 	return len(game.blocks) * len(game.blocks[0])
 }
@@ -368,10 +556,23 @@ func (game *game) areaInFloat() float32 {
 }
 
 func (game *game) totalBombs() int {
+	if game.Difficulty == custom {
+		return game.mines
+	}
 	return int(game.areaInFloat() * game.difficultyMultiplier)
 }
 
+// Returns the number of cells free of mines. Until the mines are placed in a
+// game created with the WithMaxMinesPerCell(int) option, this is the least
+// number of cells that can be free of mines.
 func (game *game) totalNonBombs() int {
+	if game.maxMinesPerCell() > 1 && game.minesPlaced {
+		mined := 0
+		game.iterateBlocksWhen(Bomb, func(*Block) {
+			mined++
+		})
+		return game.area() - mined
+	}
 	return game.area() - game.totalBombs()
 }
 
@@ -385,6 +586,30 @@ func (block *Block) Flagged() bool {
 	return block.flagged
 }
 
+// Mines returns the number of mines in a cell, which is more than one for a cell
+// holding several mines in a game created with the WithMaxMinesPerCell(int)
+// option, and zero for a cell free of mines.
+func (block *Block) Mines() int {
+	return block.mines
+}
+
+func (block *Block) addMine() {
+	block.Node = Bomb
+	block.mines++
+}
+
+// FlaggedMines returns the number carried by the flag of a cell, which can be
+// raised by flagging the cell again in a game created with the
+// WithMaxMinesPerCell(int) option, and zero for a cell that is not flagged.
+func (block *Block) FlaggedMines() int {
+	return block.flags
+}
+
+// QuestionMarked responds if a cell is marked with a question mark or not
+func (block *Block) QuestionMarked() bool {
+	return block.questioned
+}
+
 func (block Block) String() string {
 	var nodeType string
 	switch block.Node {
@@ -404,9 +629,3 @@ func (block Block) String() string {
 	return fmt.Sprintf("\n\nBlock: \n\tValue\t :\t%v\n\tLocation :\tx:%v y:%v\n\tType\t :\t%v\n\tVisited? :\t%v\n\tFlagged? :\t%v\n\n",
 		value, block.location.x, block.location.y, nodeType, block.visited, block.flagged)
 }
-
-func randomNumber(max int) int {
-	var number uint16
-	binary.Read(rand.Reader, binary.LittleEndian, &number)
-	return int(number) % max
-}
@@ -21,6 +21,7 @@ package minesweeper
 
 import (
 	"fmt"
+	mathrand "math/rand"
 	"testing"
 	"time"
 
@@ -38,11 +39,37 @@ func newBlankGame() Minesweeper {
 	return game
 }
 
-func newSampleGame() Minesweeper {
-	game, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight})
+// Creates a sample game configured by the options, so the tests of a flat board
+// also run on a board created with the WithWrapAround() option
+func newSampleGame(options ...Option) Minesweeper {
+	game, _ := NewGame(append([]Option{Grid{sampleGridWidth, sampleGridHeight}}, options...)...)
 	return game
 }
 
+// coordinates is the source of the random coordinates visited by the tests,
+// kept apart from the game's generator so that the seeded mines stay the same
+var coordinates = mathrand.New(mathrand.NewSource(time.Now().UnixNano()))
+
+// Creates a started game configured by the options with a mine at each of the
+// xy-coordinates in place of the randomly placed mines. A location given more
+// than once holds as many mines. The layers of a board created with the Grid3D
+// option follow each other along the y-coordinate.
+func newGameWithMines(options []Option, mines ...[2]int) Minesweeper {
+	minesweeper, _ := NewGame(options...)
+	minesweeper.SetMineCount(len(mines))
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+	game.clearMines()
+	for _, mine := range mines {
+		game.blocks[mine[0]][mine[1]].addMine()
+	}
+	tallyHints(game)
+	game.minesPlaced = true
+
+	return minesweeper
+}
+
 func TestGridMustNotBeSquaredForTheSakeOfTesting(t *testing.T) {
 	assert.True(t, sampleGridWidth != sampleGridHeight)
 }
@@ -111,25 +138,27 @@ func TestBombsInPlace(t *testing.T) {
 }
 
 func TestTalliedBomb(t *testing.T) {
-	minesweeper := newSampleGame()
+	testTalliedBomb(t)
+}
+
+func testTalliedBomb(t *testing.T, options ...Option) {
+	minesweeper := newSampleGame(options...)
 	minesweeper.SetDifficulty(Easy)
 	minesweeper.Play()
 
 	game := minesweeper.(*game)
-	width := game.Width
-	height := game.Height
 
 	for x, row := range game.blocks {
 		for y, block := range row {
 			if block.Node == Bomb {
-				assert.NotEqual(t, 0, hasSurroundingTally(game.blocks, width, height, x-1, y-1))
-				assert.NotEqual(t, 0, hasSurroundingTally(game.blocks, width, height, x-1, y))
-				assert.NotEqual(t, 0, hasSurroundingTally(game.blocks, width, height, x-1, y+1))
-				assert.NotEqual(t, 0, hasSurroundingTally(game.blocks, width, height, x, y-1))
-				assert.NotEqual(t, 0, hasSurroundingTally(game.blocks, width, height, x, y+1))
-				assert.NotEqual(t, 0, hasSurroundingTally(game.blocks, width, height, x+1, y-1))
-				assert.NotEqual(t, 0, hasSurroundingTally(game.blocks, width, height, x+1, y))
-				assert.NotEqual(t, 0, hasSurroundingTally(game.blocks, width, height, x+1, y+1))
+				assert.NotEqual(t, 0, hasSurroundingTally(game, x-1, y-1))
+				assert.NotEqual(t, 0, hasSurroundingTally(game, x-1, y))
+				assert.NotEqual(t, 0, hasSurroundingTally(game, x-1, y+1))
+				assert.NotEqual(t, 0, hasSurroundingTally(game, x, y-1))
+				assert.NotEqual(t, 0, hasSurroundingTally(game, x, y+1))
+				assert.NotEqual(t, 0, hasSurroundingTally(game, x+1, y-1))
+				assert.NotEqual(t, 0, hasSurroundingTally(game, x+1, y))
+				assert.NotEqual(t, 0, hasSurroundingTally(game, x+1, y+1))
 			}
 		}
 	}
@@ -138,14 +167,14 @@ func TestTalliedBomb(t *testing.T) {
 		for y, block := range row {
 			if block.Node == Number {
 				var counted int
-				counted = count(game.blocks, width, height, x-1, y-1) +
-					count(game.blocks, width, height, x-1, y) +
-					count(game.blocks, width, height, x-1, y+1) +
-					count(game.blocks, width, height, x, y-1) +
-					count(game.blocks, width, height, x, y+1) +
-					count(game.blocks, width, height, x+1, y-1) +
-					count(game.blocks, width, height, x+1, y) +
-					count(game.blocks, width, height, x+1, y+1)
+				counted = count(game, x-1, y-1) +
+					count(game, x-1, y) +
+					count(game, x-1, y+1) +
+					count(game, x, y-1) +
+					count(game, x, y+1) +
+					count(game, x+1, y-1) +
+					count(game, x+1, y) +
+					count(game, x+1, y+1)
 				assert.Equal(t, counted, block.Value)
 			}
 		}
@@ -170,6 +199,15 @@ func TestVisitedBlock(t *testing.T) {
 
 }
 
+// Creates a started game of the Easy difficulty with the same mines as the sample
+// game of the seed, so every mine can be visited in a game of its own.
+func newSeededSampleGame(seed int64) Minesweeper {
+	minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithSeed(seed))
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+	return minesweeper
+}
+
 func TestVisitedBombToGameOver(t *testing.T) {
 	minesweeper := newSampleGame()
 	minesweeper.SetDifficulty(Easy)
@@ -183,7 +221,7 @@ func TestVisitedBombToGameOver(t *testing.T) {
 		for j, block := range row {
 			if block.Node == Bomb {
 				x, y = i, j
-				_, err = game.Visit(x, y)
+				_, err = newSeededSampleGame(game.Seed()).Visit(x, y)
 				assert.Error(t, err)
 				assert.NotNil(t, err)
 				assert.IsType(t, new(ExplodedError), err)
@@ -206,7 +244,7 @@ func TestVisitedBombToGameOverWithCorrectLocationReason(t *testing.T) {
 		for j, block := range row {
 			if block.Node == Bomb {
 				x, y = i, j
-				_, err = game.Visit(x, y)
+				_, err = newSeededSampleGame(game.Seed()).Visit(x, y)
 				assert.Error(t, err)
 				assert.EqualError(t, err,
 					fmt.Sprintf("Game over at X=%v Y=%v",
@@ -219,7 +257,11 @@ func TestVisitedBombToGameOverWithCorrectLocationReason(t *testing.T) {
 }
 
 func TestVisitedUnmarkedBlockDistributeVisit(t *testing.T) {
-	minesweeper := newSampleGame()
+	testVisitedUnmarkedBlockDistributeVisit(t)
+}
+
+func testVisitedUnmarkedBlockDistributeVisit(t *testing.T, options ...Option) {
+	minesweeper := newSampleGame(options...)
 	minesweeper.SetDifficulty(Easy)
 	minesweeper.Play()
 
@@ -293,7 +335,7 @@ func TestVisitedBlocksWhenBlockIsABomb(t *testing.T) {
 	for x, row := range game.blocks {
 		for y, block := range row {
 			if block.Node == Bomb {
-				_, err := minesweeper.Visit(x, y)
+				_, err := newSeededSampleGame(game.Seed()).Visit(x, y)
 				assert.Error(t, err)
 				assert.EqualError(t, err, (&ExplodedError{x: x, y: y}).Error())
 			}
@@ -302,7 +344,11 @@ func TestVisitedBlocksWhenBlockIsABomb(t *testing.T) {
 }
 
 func TestVisitedBlockWhenBlockIsUnknownAndSpreadVisits(t *testing.T) {
-	minesweeper := newSampleGame()
+	testVisitedBlockWhenBlockIsUnknownAndSpreadVisits(t)
+}
+
+func testVisitedBlockWhenBlockIsUnknownAndSpreadVisits(t *testing.T, options ...Option) {
+	minesweeper := newSampleGame(options...)
 	minesweeper.SetDifficulty(Easy)
 	minesweeper.Play()
 
@@ -379,7 +425,11 @@ func TestBlockLocationAfterNewGame(t *testing.T) {
 }
 
 func TestCheckEventOfGameWhenWinning(t *testing.T) {
-	minesweeper, event := NewGame(Grid{sampleGridWidth, sampleGridHeight})
+	testCheckEventOfGameWhenWinning(t)
+}
+
+func testCheckEventOfGameWhenWinning(t *testing.T, options ...Option) {
+	minesweeper, event := NewGame(append([]Option{Grid{sampleGridWidth, sampleGridHeight}}, options...)...)
 	minesweeper.SetDifficulty(Easy)
 	minesweeper.Play()
 
@@ -544,8 +594,8 @@ func TestGameDoesRecordPlayersAction(t *testing.T) {
 	maxMoves := 10
 mainLoop:
 	for i := 0; i < maxMoves; i++ {
-		randomX := randomNumber(sampleGridWidth)
-		randomY := randomNumber(sampleGridHeight)
+		randomX := coordinates.Intn(sampleGridWidth)
+		randomY := coordinates.Intn(sampleGridHeight)
 		blocks, _ := minesweeper.Visit(randomX, randomY)
 
 		if blocks == nil { // Either already visited block or flagged block
@@ -638,34 +688,30 @@ func TestBlock_String(t *testing.T) {
 }
 
 func TestAttemptVisitWithoutSettingUpGameEnvironmentOfGrid(t *testing.T) {
-	defer func() {
-		if r := recover(); r != nil {
-			assert.EqualError(t, UnspecifiedGridError{}, r.(error).Error())
-		} else {
-			assert.Fail(t, "We are expecting an error when grid is not set.")
-		}
-	}()
-
 	minesweeper, _ := NewGame()
 	minesweeper.SetDifficulty(Hard)
 	minesweeper.Play()
 
-	minesweeper.Visit(0, 0)
+	_, err := minesweeper.Visit(0, 0)
+	assert.IsType(t, new(UnspecifiedGridError), err, "We are expecting an error when grid is not set.")
 }
 
 func TestAttemptVisitWithoutSettingUpGameEnvironmentOfDifficulty(t *testing.T) {
-	defer func() {
-		if r := recover(); r != nil {
-			assert.EqualError(t, UnspecifiedDifficultyError{}, r.(error).Error())
-		} else {
-			assert.Fail(t, "We are expecting an error when difficulty is not set.")
-		}
-	}()
-
 	minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight})
 	minesweeper.Play()
 
-	minesweeper.Visit(0, 0)
+	_, err := minesweeper.Visit(0, 0)
+	assert.IsType(t, new(UnspecifiedDifficultyError), err, "We are expecting an error when difficulty is not set.")
+}
+
+func TestAttemptVisitBeforePlay(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+
+	blocks, err := minesweeper.Visit(0, 0)
+	assert.Empty(t, blocks)
+	assert.IsType(t, new(GameNotStartedError), err)
+	assert.Equal(t, NotStarted, minesweeper.State().Status)
 }
 
 func TestRepeatThePlayMethodThenReturnError(t *testing.T) {
@@ -764,7 +810,11 @@ func TestUnflagBlock(t *testing.T) {
 }
 
 func TestAutoVisitNeighboringUnprobedNumbersAfterMineFlagged(t *testing.T) {
-	minesweeper, event := NewGame(Grid{sampleGridWidth, sampleGridHeight})
+	testAutoVisitNeighboringUnprobedNumbersAfterMineFlagged(t)
+}
+
+func testAutoVisitNeighboringUnprobedNumbersAfterMineFlagged(t *testing.T, options ...Option) {
+	minesweeper, event := NewGame(append([]Option{Grid{sampleGridWidth, sampleGridHeight}}, options...)...)
 	minesweeper.SetDifficulty(Easy)
 	minesweeper.Play()
 
@@ -807,7 +857,11 @@ func TestAutoVisitNeighboringUnprobedNumbersAfterMineFlagged(t *testing.T) {
 }
 
 func TestAutoVisitWithWronglyFlaggedBomb(t *testing.T) {
-	minesweeper, _ := NewGame(Grid{10, 10})
+	testAutoVisitWithWronglyFlaggedBomb(t)
+}
+
+func testAutoVisitWithWronglyFlaggedBomb(t *testing.T, options ...Option) {
+	minesweeper, _ := NewGame(append([]Option{Grid{10, 10}}, options...)...)
 	minesweeper.SetDifficulty(Easy)
 	minesweeper.Play()
 
@@ -845,7 +899,11 @@ func TestAutoVisitWithWronglyFlaggedBomb(t *testing.T) {
 }
 
 func TestDoNotAutoVisitIfAllNeighboringBombsAreNotFlagged(t *testing.T) {
-	minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight})
+	testDoNotAutoVisitIfAllNeighboringBombsAreNotFlagged(t)
+}
+
+func testDoNotAutoVisitIfAllNeighboringBombsAreNotFlagged(t *testing.T, options ...Option) {
+	minesweeper, _ := NewGame(append([]Option{Grid{sampleGridWidth, sampleGridHeight}}, options...)...)
 	minesweeper.SetDifficulty(Easy)
 	minesweeper.Play()
 
@@ -876,19 +934,28 @@ func appendBomb(game *game, x, y int, bombs []*Block, nonBombs []*Block) ([]*Blo
 	return bombs, nonBombs
 }
 
-func count(blocks blocks, width, height, x, y int) (has int) {
-	if x >= 0 && y >= 0 &&
-		x < width && y < height &&
-		blocks[x][y].Node&Bomb == Bomb {
+// Returns the block at the xy-coordinates, which are carried across the edges of
+// a board whose edges wrap around, or nil if the block is outside of the board
+func blockAt(game *game, x, y int) *Block {
+	if game.wrapAround {
+		x, y = (x+game.Width)%game.Width, (y+game.Height)%game.Height
+	}
+	if x >= 0 && y >= 0 && x < game.Width && y < game.Height {
+		return &game.blocks[x][y]
+	}
+	return nil
+}
+
+func count(game *game, x, y int) (has int) {
+	if block := blockAt(game, x, y); block != nil && block.Node&Bomb == Bomb {
 		return 1
 	}
 	return
 }
 
-func hasSurroundingTally(blocks blocks, width, height, x, y int) int {
-	if x >= 0 && y >= 0 &&
-		x < width && y < height {
-		switch blocks[x][y].Node {
+func hasSurroundingTally(game *game, x, y int) int {
+	if block := blockAt(game, x, y); block != nil {
+		switch block.Node {
 		case Number:
 			return 1
 		case Bomb:
@@ -914,3 +981,164 @@ func print(game *game) {
 		}
 	}
 }
+
+func TestGame_SetMineCount(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetMineCount(33)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+	assert.Equal(t, 33, game.totalBombs())
+	assert.Equal(t, 33, len(game.BombLocations()))
+}
+
+func TestSetMineCountOverridesDifficulty(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Hard)
+	minesweeper.SetMineCount(5)
+	minesweeper.Play()
+
+	assert.Equal(t, 5, len(minesweeper.(*game).BombLocations()))
+}
+
+func TestCannotChangeMineCountOnceGameIsStarted(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetMineCount(5)
+	minesweeper.Play()
+
+	assert.EqualError(t, minesweeper.SetMineCount(6), GameAlreadyStartedError{}.Error())
+}
+
+func TestPlayGameWithTooManyMines(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetMineCount(sampleGridWidth * sampleGridHeight)
+	err := minesweeper.Play()
+
+	assert.Equal(t, &InvalidMineCountError{mines: sampleGridWidth * sampleGridHeight, grid: Grid{sampleGridWidth, sampleGridHeight}}, err)
+	assert.Nil(t, minesweeper.(*game).Mutex)
+}
+
+func TestPlayGameWithNegativeMines(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetMineCount(-1)
+
+	assert.IsType(t, new(InvalidMineCountError), minesweeper.Play())
+}
+
+func TestPlayGameWithAllButOneCellMined(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{3, 3}, WithSafeFirstVisit())
+	minesweeper.SetMineCount(8)
+	assert.NoError(t, minesweeper.Play())
+
+	blocks, err := minesweeper.Visit(1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, blocks[0].Value)
+}
+
+func TestClassicPresets(t *testing.T) {
+	for _, preset := range []CustomDifficulty{Beginner, Intermediate, Expert} {
+		minesweeper, _ := NewGame(preset)
+		assert.NoError(t, minesweeper.Play())
+
+		game := minesweeper.(*game)
+		assert.Equal(t, &Grid{preset.Width, preset.Height}, game.Grid)
+		assert.Equal(t, preset.Mines, len(game.BombLocations()))
+	}
+}
+
+func TestVisitOutOfBounds(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	for _, coordinates := range [][2]int{{-1, 0}, {0, -1}, {sampleGridWidth, 0}, {0, sampleGridHeight}} {
+		blocks, err := minesweeper.Visit(coordinates[0], coordinates[1])
+		assert.Nil(t, blocks)
+		assert.Equal(t, &OutOfBoundsError{x: coordinates[0], y: coordinates[1], grid: Grid{sampleGridWidth, sampleGridHeight}}, err)
+	}
+}
+
+func TestFlagOutOfBounds(t *testing.T) {
+	minesweeper := newSampleGame()
+
+	for _, coordinates := range [][2]int{{-1, 0}, {0, -1}, {sampleGridWidth, 0}, {0, sampleGridHeight}} {
+		err := minesweeper.Flag(coordinates[0], coordinates[1])
+		assert.Equal(t, &OutOfBoundsError{x: coordinates[0], y: coordinates[1], grid: Grid{sampleGridWidth, sampleGridHeight}}, err)
+	}
+}
+
+func TestFlagWithoutSettingGrid(t *testing.T) {
+	minesweeper := newBlankGame()
+	assert.Equal(t, new(UnspecifiedGridError), minesweeper.Flag(0, 0))
+}
+
+func TestQuestionMarkCycle(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithQuestionMarks())
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	block := &minesweeper.(*game).blocks[3][6]
+
+	minesweeper.Flag(3, 6)
+	assert.True(t, block.Flagged())
+	assert.False(t, block.QuestionMarked())
+
+	minesweeper.Flag(3, 6)
+	assert.False(t, block.Flagged())
+	assert.True(t, block.QuestionMarked())
+	assert.Equal(t, 0, minesweeper.State().FlagsPlaced)
+
+	minesweeper.Flag(3, 6)
+	assert.False(t, block.Flagged())
+	assert.False(t, block.QuestionMarked())
+}
+
+func TestNoQuestionMarkWithoutOption(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	minesweeper.Flag(3, 6)
+	minesweeper.Flag(3, 6)
+	assert.False(t, minesweeper.(*game).blocks[3][6].QuestionMarked())
+}
+
+func TestVisitQuestionMarkedBlock(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithQuestionMarks())
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node == Number {
+				minesweeper.Flag(x, y)
+				minesweeper.Flag(x, y)
+
+				blocks, err := minesweeper.Visit(x, y)
+				assert.NoError(t, err)
+				assert.Equal(t, 1, len(blocks))
+				assert.True(t, game.blocks[x][y].visited)
+				assert.False(t, game.blocks[x][y].QuestionMarked())
+				return
+			}
+		}
+	}
+}
+
+func TestGameDoesRecordMarkingActions(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithQuestionMarks())
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	var story visited.StoryTeller = minesweeper.(*game)
+
+	expectedActions := []visited.Action{visited.Flag, visited.QuestionMark, visited.Unmark}
+	for _, expectedAction := range expectedActions {
+		minesweeper.Flag(3, 6)
+		assert.Equal(t, expectedAction, story.LastAction().Action)
+		assert.Equal(t, 3, story.LastAction().X())
+		assert.Equal(t, 6, story.LastAction().Y())
+	}
+}
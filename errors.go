@@ -23,13 +23,29 @@ import "fmt"
 
 // ExplodedError is the error type used to handle a situation when a mine is visited
 type ExplodedError struct {
-	x, y int
+	x, y, z int
 }
 
 func (Exploded ExplodedError) Error() string {
+	if Exploded.z > 0 {
+		return fmt.Sprintf("Game over at X=%v Y=%v Z=%v", Exploded.x, Exploded.y, Exploded.z)
+	}
 	return fmt.Sprintf("Game over at X=%v Y=%v", Exploded.x, Exploded.y)
 }
 
+// GameOverError is the error type used to handle errors when a cell is visited,
+// flagged or chorded after the game is won or lost.
+type GameOverError struct {
+	status Status
+}
+
+func (GameOver GameOverError) Error() string {
+	if GameOver.status == Won {
+		return "The game is already won."
+	}
+	return "The game is already lost."
+}
+
 // GameAlreadyStartedError is the error type used to handle errors when attempting
 // to restart the game, changing the Grid size when the game is started, or changing
 // the difficulty while the game is started.
@@ -39,6 +55,14 @@ func (GameAlreadyStarted GameAlreadyStartedError) Error() string {
 	return "Game already started. Try setting a new board."
 }
 
+// GameNotStartedError is the error type used to handle errors when a cell is visited
+// before the Play() method is called.
+type GameNotStartedError struct{}
+
+func (GameNotStarted GameNotStartedError) Error() string {
+	return "Game not started yet. Call Play() before visiting a cell."
+}
+
 // UnspecifiedDifficultyError is the error type used to handle errors when the Play()
 // method is called but the Difficulty is not set in the game.
 type UnspecifiedDifficultyError struct{}
@@ -54,3 +78,123 @@ type UnspecifiedGridError struct{}
 func (UnspecifiedGrid UnspecifiedGridError) Error() string {
 	return "Grid was not specified. Pass a Grid object with the corresponding coordinates before calling Play()."
 }
+
+// OutOfBoundsError is the error type used to handle errors when a cell is visited or
+// flagged with coordinates outside the Grid size.
+type OutOfBoundsError struct {
+	x, y, z int
+	grid    Grid
+	depth   int
+}
+
+func (OutOfBounds OutOfBoundsError) Error() string {
+	if OutOfBounds.depth > 1 {
+		return fmt.Sprintf("Cell at X=%v Y=%v Z=%v is out of bounds of the %vx%vx%v grid.",
+			OutOfBounds.x, OutOfBounds.y, OutOfBounds.z, OutOfBounds.grid.Width, OutOfBounds.grid.Height, OutOfBounds.depth)
+	}
+	return fmt.Sprintf("Cell at X=%v Y=%v is out of bounds of the %vx%v grid.",
+		OutOfBounds.x, OutOfBounds.y, OutOfBounds.grid.Width, OutOfBounds.grid.Height)
+}
+
+// InvalidMineCountError is the error type used to handle errors when the Play() method
+// is called but the number of mines is negative or does not fit the Grid size.
+type InvalidMineCountError struct {
+	mines           int
+	grid            Grid
+	depth           int
+	maxMinesPerCell int
+}
+
+func (InvalidMineCount InvalidMineCountError) Error() string {
+	size := fmt.Sprintf("%vx%v", InvalidMineCount.grid.Width, InvalidMineCount.grid.Height)
+	if InvalidMineCount.depth > 1 {
+		size = fmt.Sprintf("%vx%v", size, InvalidMineCount.depth)
+	}
+	if InvalidMineCount.maxMinesPerCell > 1 {
+		return fmt.Sprintf("Cannot place %v mines in a %v grid of up to %v mines per cell. At least one cell must be free of mines.",
+			InvalidMineCount.mines, size, InvalidMineCount.maxMinesPerCell)
+	}
+	return fmt.Sprintf("Cannot place %v mines in a %v grid. The number of mines must be less than the number of cells.",
+		InvalidMineCount.mines, size)
+}
+
+// OddWrapAroundWidthError is the error type used to handle errors when the Play() method
+// is called on a board of the minesweeper.Hexagonal topology created with the
+// WithWrapAround() option but whose width is odd.
+type OddWrapAroundWidthError struct {
+	grid Grid
+}
+
+func (OddWrapAroundWidth OddWrapAroundWidthError) Error() string {
+	return fmt.Sprintf("Cannot wrap around the %vx%v hexagonal grid. The width must be even.",
+		OddWrapAroundWidth.grid.Width, OddWrapAroundWidth.grid.Height)
+}
+
+// NoGuessMultiMineError is the error type used to handle errors when the Play() method
+// is called on a game created with both the WithNoGuess() option and the
+// WithMaxMinesPerCell(int) option allowing several mines per cell.
+type NoGuessMultiMineError struct {
+	maxMinesPerCell int
+}
+
+func (NoGuessMultiMine NoGuessMultiMineError) Error() string {
+	return fmt.Sprintf("Cannot guarantee a board solvable without guessing with up to %v mines per cell.", NoGuessMultiMine.maxMinesPerCell)
+}
+
+// UnsolvableBoardError is the error type used to handle errors when the game, created
+// with the WithNoGuess() option, fails to generate a board that can be solved without
+// guessing from the first visited cell.
+type UnsolvableBoardError struct {
+	attempts int
+}
+
+func (UnsolvableBoard UnsolvableBoardError) Error() string {
+	return fmt.Sprintf("No board solvable without guessing was found after %v attempts. Try a lower difficulty or a larger board.", UnsolvableBoard.attempts)
+}
+
+// NothingToUndoError is the error type used to handle errors when the Undo() method is
+// called but no move has been made or all moves are already undone.
+type NothingToUndoError struct{}
+
+func (NothingToUndo NothingToUndoError) Error() string {
+	return "No move to undo."
+}
+
+// NothingToRedoError is the error type used to handle errors when the Redo() method is
+// called but no move has been undone since the last move was made.
+type NothingToRedoError struct{}
+
+func (NothingToRedo NothingToRedoError) Error() string {
+	return "No move to redo."
+}
+
+// UnsupportedSnapshotError is the error type used to handle errors when a game is loaded
+// from a snapshot whose version is not supported.
+type UnsupportedSnapshotError struct {
+	version int
+}
+
+func (UnsupportedSnapshot UnsupportedSnapshotError) Error() string {
+	return fmt.Sprintf("Snapshot version %v is not supported.", UnsupportedSnapshot.version)
+}
+
+// InvalidSnapshotError is the error type used to handle errors when a game is loaded
+// from a snapshot whose board cannot be created, such as a Grid without any cell
+// or a negative depth.
+type InvalidSnapshotError struct {
+	field string
+	value int
+}
+
+func (InvalidSnapshot InvalidSnapshotError) Error() string {
+	return fmt.Sprintf("Snapshot has an invalid %v of %v.", InvalidSnapshot.field, InvalidSnapshot.value)
+}
+
+// MinesNotPlacedError is the error type used to handle errors when the board is analyzed
+// before its mines are placed by the Play() method or, for a game created with the
+// WithSafeFirstVisit() option, by the first visit.
+type MinesNotPlacedError struct{}
+
+func (MinesNotPlaced MinesNotPlacedError) Error() string {
+	return "The mines are not placed yet."
+}
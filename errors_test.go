@@ -44,3 +44,78 @@ func TestUnspecifiedGrid_Error(t *testing.T) {
 	err := UnspecifiedGridError{}
 	assert.EqualError(t, err, "Grid was not specified. Pass a Grid object with the corresponding coordinates before calling Play().")
 }
+
+func TestOutOfBounds_Error(t *testing.T) {
+	err := OutOfBoundsError{x: 10, y: -1, grid: Grid{10, 40}}
+	assert.EqualError(t, err, "Cell at X=10 Y=-1 is out of bounds of the 10x40 grid.")
+}
+
+func TestOutOfBounds3D_Error(t *testing.T) {
+	err := OutOfBoundsError{x: 1, y: 2, z: 3, grid: Grid{4, 4}, depth: 3}
+	assert.EqualError(t, err, "Cell at X=1 Y=2 Z=3 is out of bounds of the 4x4x3 grid.")
+}
+
+func TestExploded3D_Error(t *testing.T) {
+	err := ExplodedError{x: 1, y: 2, z: 3}
+	assert.EqualError(t, err, "Game over at X=1 Y=2 Z=3")
+}
+
+func TestInvalidMineCount3D_Error(t *testing.T) {
+	err := InvalidMineCountError{mines: 27, grid: Grid{3, 3}, depth: 3}
+	assert.EqualError(t, err, "Cannot place 27 mines in a 3x3x3 grid. The number of mines must be less than the number of cells.")
+}
+
+func TestInvalidMineCount_Error(t *testing.T) {
+	err := InvalidMineCountError{mines: 82, grid: Grid{9, 9}}
+	assert.EqualError(t, err, "Cannot place 82 mines in a 9x9 grid. The number of mines must be less than the number of cells.")
+}
+
+func TestUnsolvableBoard_Error(t *testing.T) {
+	err := UnsolvableBoardError{attempts: 1000}
+	assert.EqualError(t, err, "No board solvable without guessing was found after 1000 attempts. Try a lower difficulty or a larger board.")
+}
+
+func TestNothingToUndo_Error(t *testing.T) {
+	err := NothingToUndoError{}
+	assert.EqualError(t, err, "No move to undo.")
+}
+
+func TestNothingToRedo_Error(t *testing.T) {
+	err := NothingToRedoError{}
+	assert.EqualError(t, err, "No move to redo.")
+}
+
+func TestUnsupportedSnapshot_Error(t *testing.T) {
+	err := UnsupportedSnapshotError{version: 2}
+	assert.EqualError(t, err, "Snapshot version 2 is not supported.")
+}
+
+func TestInvalidMineCountWithMaxMinesPerCell_Error(t *testing.T) {
+	err := InvalidMineCountError{mines: 17, grid: Grid{3, 3}, maxMinesPerCell: 2}
+	assert.EqualError(t, err, "Cannot place 17 mines in a 3x3 grid of up to 2 mines per cell. At least one cell must be free of mines.")
+}
+
+func TestGameOver_Error(t *testing.T) {
+	assert.EqualError(t, GameOverError{status: Won}, "The game is already won.")
+	assert.EqualError(t, GameOverError{status: Lost}, "The game is already lost.")
+}
+
+func TestOddWrapAroundWidth_Error(t *testing.T) {
+	err := OddWrapAroundWidthError{grid: Grid{5, 6}}
+	assert.EqualError(t, err, "Cannot wrap around the 5x6 hexagonal grid. The width must be even.")
+}
+
+func TestNoGuessMultiMine_Error(t *testing.T) {
+	err := NoGuessMultiMineError{maxMinesPerCell: 3}
+	assert.EqualError(t, err, "Cannot guarantee a board solvable without guessing with up to 3 mines per cell.")
+}
+
+func TestInvalidSnapshot_Error(t *testing.T) {
+	err := InvalidSnapshotError{field: "grid width", value: -1}
+	assert.EqualError(t, err, "Snapshot has an invalid grid width of -1.")
+}
+
+func TestGameNotStarted_Error(t *testing.T) {
+	err := GameNotStartedError{}
+	assert.EqualError(t, err, "Game not started yet. Call Play() before visiting a cell.")
+}
@@ -0,0 +1,216 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import (
+	"sync"
+	"time"
+)
+
+// EventKind is the type of the game's event delivered to a Subscription.
+// Values of this type are minesweeper.GameStarted, minesweeper.CellRevealed,
+// minesweeper.CellFlagged, minesweeper.CellUnflagged, minesweeper.Chorded,
+// minesweeper.Exploded, minesweeper.GameWon, minesweeper.MoveUndone,
+// minesweeper.MoveRedone and minesweeper.CellQuestionMarked
+type EventKind uint8
+
+const (
+	// GameStarted is the event kind delivered when the Play() method is called.
+	GameStarted EventKind = iota + 1
+
+	// CellRevealed is the event kind delivered when a visited cell reveals one
+	// or more cells.
+	CellRevealed
+
+	// CellFlagged is the event kind delivered when a cell is flagged.
+	CellFlagged
+
+	// CellUnflagged is the event kind delivered when a flagged or question-marked
+	// cell is flagged again, removing its mark.
+	CellUnflagged
+
+	// Chorded is the event kind delivered when an already visited warning number
+	// is visited again and its unflagged neighboring cells are revealed.
+	Chorded
+
+	// Exploded is the event kind delivered when a mine is visited. The game is lost.
+	Exploded
+
+	// GameWon is the event kind delivered when all non-mine cells are visited.
+	GameWon
+
+	// MoveUndone is the event kind delivered when the last move is undone.
+	MoveUndone
+
+	// MoveRedone is the event kind delivered when the last undone move is redone.
+	MoveRedone
+
+	// CellQuestionMarked is the event kind delivered when a flagged cell is
+	// flagged again in a game created with the WithQuestionMarks() option.
+	CellQuestionMarked
+)
+
+// GameEvent is the event delivered to a Subscription. Every event of a game has
+// a sequence number one greater than the event before it.
+type GameEvent struct {
+	Kind     EventKind
+	Sequence uint64
+	Time     time.Time
+
+	// X, Y and Z are the coordinates of the cell visited or flagged by the
+	// player that caused the event, where Z is the layer of a board created with
+	// the Grid3D option. For the Exploded event, these are the coordinates of
+	// the visited mine.
+	X, Y, Z int
+
+	// Blocks are the cells revealed by the CellRevealed and Chorded events and
+	// all the mines revealed by the Exploded event, with the visited mine as the
+	// first element. For the MoveUndone and MoveRedone events, these are the cells
+	// whose visited or flagged state is changed.
+	Blocks []Block
+}
+
+// Subscription receives the events of a game in the order they happened. Events
+// are queued for each subscription, so a slow subscriber never blocks the game
+// nor the other subscribers, and no event is dropped until Close() is called.
+type Subscription struct {
+	events  chan GameEvent
+	done    chan struct{}
+	pending []GameEvent
+	closed  bool
+	cond    *sync.Cond
+}
+
+type publisher struct {
+	subscriptionsLock sync.Mutex
+	subscriptions     []*Subscription
+	sequence          uint64
+}
+
+// Events returns the channel that receives the events of the subscription. The
+// channel is closed once Close() is called.
+func (subscription *Subscription) Events() <-chan GameEvent {
+	return subscription.events
+}
+
+// Close stops the subscription from receiving events. Events that are not yet
+// received from the Events() channel are discarded.
+func (subscription *Subscription) Close() {
+	subscription.cond.L.Lock()
+	defer subscription.cond.L.Unlock()
+
+	if !subscription.closed {
+		subscription.closed = true
+		subscription.pending = nil
+		close(subscription.done)
+		subscription.cond.Broadcast()
+	}
+}
+
+func newSubscription() *Subscription {
+	subscription := &Subscription{
+		events: make(chan GameEvent),
+		done:   make(chan struct{}),
+		cond:   sync.NewCond(new(sync.Mutex)),
+	}
+	go subscription.deliver()
+	return subscription
+}
+
+func (subscription *Subscription) enqueue(event GameEvent) bool {
+	subscription.cond.L.Lock()
+	defer subscription.cond.L.Unlock()
+
+	if subscription.closed {
+		return false
+	}
+	subscription.pending = append(subscription.pending, event)
+	subscription.cond.Signal()
+	return true
+}
+
+func (subscription *Subscription) deliver() {
+	defer close(subscription.events)
+
+	for {
+		subscription.cond.L.Lock()
+		for len(subscription.pending) == 0 && !subscription.closed {
+			subscription.cond.Wait()
+		}
+		if subscription.closed {
+			subscription.cond.L.Unlock()
+			return
+		}
+		event := subscription.pending[0]
+		subscription.pending = subscription.pending[1:]
+		subscription.cond.L.Unlock()
+
+		select {
+		case subscription.events <- event:
+		case <-subscription.done:
+			return
+		}
+	}
+}
+
+func (publisher *publisher) Subscribe() *Subscription {
+	publisher.subscriptionsLock.Lock()
+	defer publisher.subscriptionsLock.Unlock()
+
+	subscription := newSubscription()
+	publisher.subscriptions = append(publisher.subscriptions, subscription)
+	return subscription
+}
+
+func (publisher *publisher) publish(event GameEvent) {
+	publisher.subscriptionsLock.Lock()
+	defer publisher.subscriptionsLock.Unlock()
+
+	publisher.sequence++
+	event.Sequence = publisher.sequence
+	event.Time = time.Now()
+
+	subscriptions := publisher.subscriptions[:0]
+	for _, subscription := range publisher.subscriptions {
+		if subscription.enqueue(event) {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	publisher.subscriptions = subscriptions
+}
+
+func (game *game) publishVisit(x, y, z int, chording bool, blocks []Block, err error) {
+	blocks = append([]Block(nil), blocks...)
+
+	if exploded, ok := err.(*ExplodedError); ok {
+		game.publish(GameEvent{Kind: Exploded, X: exploded.x, Y: exploded.y, Z: exploded.z, Blocks: blocks})
+		return
+	}
+
+	kind := CellRevealed
+	if chording {
+		kind = Chorded
+	}
+	game.publish(GameEvent{Kind: kind, X: x, Y: y, Z: z, Blocks: blocks})
+
+	if game.status() == Won {
+		game.publish(GameEvent{Kind: GameWon, X: x, Y: y, Z: z})
+	}
+}
@@ -0,0 +1,231 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func receive(t *testing.T, subscription *Subscription) GameEvent {
+	select {
+	case event := <-subscription.Events():
+		return event
+	case <-time.After(5 * time.Second):
+		assert.FailNow(t, "Was expecting an event in less than 5 seconds of runtime")
+		return GameEvent{}
+	}
+}
+
+func TestSubscriptionReceivesEventsInOrder(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	subscription := minesweeper.Subscribe()
+	defer subscription.Close()
+
+	minesweeper.Play()
+	minesweeper.Flag(0, 0)
+	minesweeper.Flag(0, 0)
+
+	started := receive(t, subscription)
+	assert.Equal(t, GameStarted, started.Kind)
+	assert.Equal(t, uint64(1), started.Sequence)
+
+	flagged := receive(t, subscription)
+	assert.Equal(t, CellFlagged, flagged.Kind)
+	assert.Equal(t, uint64(2), flagged.Sequence)
+	assert.Equal(t, 0, flagged.X)
+	assert.Equal(t, 0, flagged.Y)
+
+	unflagged := receive(t, subscription)
+	assert.Equal(t, CellUnflagged, unflagged.Kind)
+	assert.Equal(t, uint64(3), unflagged.Sequence)
+	assert.False(t, unflagged.Time.Before(flagged.Time))
+}
+
+func TestMultipleSubscriptionsReceiveSameEvents(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	first := minesweeper.Subscribe()
+	second := minesweeper.Subscribe()
+	defer first.Close()
+	defer second.Close()
+
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+	var moves int
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node == Number && moves < 100 {
+				minesweeper.Visit(x, y)
+				moves++
+			}
+		}
+	}
+
+	for i := 0; i <= moves; i++ {
+		event := receive(t, first)
+		assert.Equal(t, event, receive(t, second))
+		assert.Equal(t, uint64(i+1), event.Sequence)
+	}
+}
+
+func TestCellRevealedEvent(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+	subscription := minesweeper.Subscribe()
+	defer subscription.Close()
+
+	game := minesweeper.(*game)
+
+mainLoop:
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node == Unknown {
+				blocks, _ := minesweeper.Visit(x, y)
+
+				event := receive(t, subscription)
+				assert.Equal(t, CellRevealed, event.Kind)
+				assert.Equal(t, x, event.X)
+				assert.Equal(t, y, event.Y)
+				assert.Equal(t, blocks, event.Blocks)
+				break mainLoop
+			}
+		}
+	}
+}
+
+func TestExplodedEvent(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+	subscription := minesweeper.Subscribe()
+	defer subscription.Close()
+
+	game := minesweeper.(*game)
+
+mainLoop:
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node == Bomb {
+				blocks, _ := minesweeper.Visit(x, y)
+
+				event := receive(t, subscription)
+				assert.Equal(t, Exploded, event.Kind)
+				assert.Equal(t, x, event.X)
+				assert.Equal(t, y, event.Y)
+				assert.Equal(t, blocks, event.Blocks)
+				break mainLoop
+			}
+		}
+	}
+}
+
+func TestChordedEvent(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node == Number && !block.visited {
+				minesweeper.Visit(x, y)
+				game.traverseAdjacentCells(x, y, func(cell *Block) {
+					if cell.Node == Bomb && !cell.flagged {
+						minesweeper.Flag(cell.X(), cell.Y())
+					}
+				})
+
+				subscription := minesweeper.Subscribe()
+				blocks, _ := minesweeper.Visit(x, y)
+				if len(blocks) > 0 {
+					event := receive(t, subscription)
+					assert.Equal(t, Chorded, event.Kind)
+					assert.Equal(t, blocks, event.Blocks)
+				}
+				subscription.Close()
+				return
+			}
+		}
+	}
+}
+
+func TestGameWonEvent(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+	subscription := minesweeper.Subscribe()
+	defer subscription.Close()
+
+	game := minesweeper.(*game)
+
+	var moves int
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node != Bomb && !block.visited {
+				minesweeper.Visit(x, y)
+				moves++
+			}
+		}
+	}
+
+	var event GameEvent
+	for i := 0; i <= moves; i++ {
+		event = receive(t, subscription)
+	}
+	assert.Equal(t, GameWon, event.Kind)
+}
+
+func TestClosedSubscriptionClosesChannel(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	subscription := minesweeper.Subscribe()
+	minesweeper.Play()
+
+	subscription.Close()
+	minesweeper.Flag(0, 0)
+
+	for range subscription.Events() {
+	}
+	assert.Empty(t, minesweeper.(*game).subscriptions)
+}
+
+func TestCellQuestionMarkedEvent(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithQuestionMarks())
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+	subscription := minesweeper.Subscribe()
+	defer subscription.Close()
+
+	minesweeper.Flag(3, 6)
+	minesweeper.Flag(3, 6)
+	minesweeper.Flag(3, 6)
+
+	assert.Equal(t, CellFlagged, receive(t, subscription).Kind)
+	assert.Equal(t, CellQuestionMarked, receive(t, subscription).Kind)
+	assert.Equal(t, CellUnflagged, receive(t, subscription).Kind)
+}
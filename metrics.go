@@ -0,0 +1,256 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+// BoardMetrics is the analysis of the board's mine layout and of the player's
+// performance at the time the Metrics() method is called.
+type BoardMetrics struct {
+	// ThreeBV is the Bechtel's Board Benchmark Value, the least number of visits
+	// needed to clear the board without flagging: one for every opening and one
+	// for every warning number outside of the openings.
+	ThreeBV int
+
+	// Openings is the number of connected regions of cells with no warning
+	// number, each cleared together with its bordering cells by a single visit.
+	Openings int
+
+	// IsolatedNumbers is the number of cells with a warning number that do not
+	// border any opening.
+	IsolatedNumbers int
+
+	// ZiNi is an estimate of the least number of visits, flags and chords needed
+	// to clear the board, found by repeatedly chording the warning number that
+	// saves the most clicks.
+	ZiNi int
+
+	// SolvedThreeBV is the part of the ThreeBV already cleared by the player,
+	// which equals the ThreeBV once the game is won.
+	SolvedThreeBV int
+
+	// Clicks is the number of moves recorded in the player's history, counting
+	// every visit, flag, mark and chord.
+	Clicks int
+
+	// ThreeBVPerSecond is the SolvedThreeBV divided by the elapsed seconds of
+	// the game.
+	ThreeBVPerSecond float64
+
+	// Efficiency is the SolvedThreeBV divided by the Clicks.
+	Efficiency float64
+}
+
+// analysis partitions the non-mine cells of the board into the units that each
+// need a visit to be cleared: the openings and the isolated numbers
+type analysis struct {
+	*game
+
+	// opening is the index of the opening of every cell with no warning number,
+	// and members are the cells cleared by visiting each opening
+	opening  map[*Block]int
+	members  [][]*Block
+	isolated map[*Block]bool
+}
+
+func (game *game) Metrics() (BoardMetrics, error) {
+	if game.Grid == nil {
+		return BoardMetrics{}, new(UnspecifiedGridError)
+	}
+
+	if game.Mutex != nil {
+		game.Lock()
+		defer game.Unlock()
+	}
+
+	if !game.minesPlaced {
+		return BoardMetrics{}, new(MinesNotPlacedError)
+	}
+
+	analysis := game.analyze()
+	metrics := BoardMetrics{
+		ThreeBV:         len(analysis.members) + len(analysis.isolated),
+		Openings:        len(analysis.members),
+		IsolatedNumbers: len(analysis.isolated),
+		ZiNi:            analysis.zini(),
+		SolvedThreeBV:   analysis.solved(),
+	}
+
+	for history := game.recordedActions.History; history != nil; history = history.History {
+		metrics.Clicks++
+	}
+	if seconds := game.elapsed().Seconds(); seconds > 0 {
+		metrics.ThreeBVPerSecond = float64(metrics.SolvedThreeBV) / seconds
+	}
+	if metrics.Clicks > 0 {
+		metrics.Efficiency = float64(metrics.SolvedThreeBV) / float64(metrics.Clicks)
+	}
+	return metrics, nil
+}
+
+func (game *game) analyze() *analysis {
+	analysis := &analysis{game: game, opening: make(map[*Block]int), isolated: make(map[*Block]bool)}
+
+	game.iterateBlocks(func(block *Block) bool {
+		if block.Node != Unknown {
+			return true
+		}
+		if _, ok := analysis.opening[block]; ok {
+			return true
+		}
+
+		index := len(analysis.members)
+		analysis.opening[block] = index
+		members := []*Block{block}
+		bordering := make(map[*Block]bool)
+		for queue := []*Block{block}; len(queue) > 0; queue = queue[1:] {
+			game.traverseAdjacentCells(queue[0].X(), game.row(queue[0]), func(cell *Block) {
+				switch cell.Node {
+				case Unknown:
+					if _, ok := analysis.opening[cell]; !ok {
+						analysis.opening[cell] = index
+						members = append(members, cell)
+						queue = append(queue, cell)
+					}
+				case Number:
+					if !bordering[cell] {
+						bordering[cell] = true
+						members = append(members, cell)
+					}
+				}
+			})
+		}
+		analysis.members = append(analysis.members, members)
+		return true
+	})
+
+	bordering := make(map[*Block]bool)
+	for _, members := range analysis.members {
+		for _, member := range members {
+			bordering[member] = true
+		}
+	}
+	game.iterateBlocks(func(block *Block) bool {
+		if block.Node == Number && !bordering[block] {
+			analysis.isolated[block] = true
+		}
+		return true
+	})
+
+	return analysis
+}
+
+// Returns the number of openings and isolated numbers visited by the player
+func (analysis *analysis) solved() int {
+	var solved int
+	for _, members := range analysis.members {
+		if members[0].visited {
+			solved++
+		}
+	}
+	for block := range analysis.isolated {
+		if block.visited {
+			solved++
+		}
+	}
+	return solved
+}
+
+// Clears the board from scratch by chording the warning number with the highest
+// premium, which is the number of openings and isolated numbers cleared by the
+// chord minus the clicks to flag its mines and to chord it, until no chord saves
+// any click. Every opening and isolated number left takes a visit.
+func (analysis *analysis) zini() int {
+	revealed := make(map[*Block]bool)
+	flagged := make(map[*Block]bool)
+	var clicks int
+
+	reveal := func(block *Block) {
+		if index, ok := analysis.opening[block]; ok {
+			for _, member := range analysis.members[index] {
+				revealed[member] = true
+			}
+		}
+		revealed[block] = true
+	}
+
+	premium := func(block *Block) int {
+		units := make(map[interface{}]bool)
+		premium := -1
+		if !revealed[block] && !analysis.isolated[block] {
+			premium--
+		}
+		analysis.traverseAdjacentCells(block.X(), analysis.row(block), func(cell *Block) {
+			switch {
+			case cell.Node == Bomb && !flagged[cell]:
+				premium -= cell.Mines()
+			case revealed[cell] || cell.Node == Bomb:
+			case cell.Node == Unknown:
+				units[analysis.opening[cell]] = true
+			case analysis.isolated[cell]:
+				units[cell] = true
+			}
+		})
+		return premium + len(units)
+	}
+
+	for {
+		var best *Block
+		var highest int
+		analysis.iterateBlocks(func(block *Block) bool {
+			if block.Node == Number {
+				if premium := premium(block); premium > highest {
+					best, highest = block, premium
+				}
+			}
+			return true
+		})
+		if best == nil {
+			break
+		}
+
+		if !revealed[best] {
+			clicks++
+			reveal(best)
+		}
+		analysis.traverseAdjacentCells(best.X(), analysis.row(best), func(cell *Block) {
+			if cell.Node == Bomb && !flagged[cell] {
+				flagged[cell] = true
+				clicks += cell.Mines()
+			}
+		})
+		clicks++
+		analysis.traverseAdjacentCells(best.X(), analysis.row(best), func(cell *Block) {
+			if cell.Node != Bomb {
+				reveal(cell)
+			}
+		})
+	}
+
+	for _, members := range analysis.members {
+		if !revealed[members[0]] {
+			clicks++
+		}
+	}
+	for block := range analysis.isolated {
+		if !revealed[block] {
+			clicks++
+		}
+	}
+	return clicks
+}
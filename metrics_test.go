@@ -0,0 +1,124 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsOfSingleOpening(t *testing.T) {
+	metrics, err := newGameWithMines([]Option{Grid{5, 5}}, [2]int{2, 2}).Metrics()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, metrics.ThreeBV)
+	assert.Equal(t, 1, metrics.Openings)
+	assert.Equal(t, 0, metrics.IsolatedNumbers)
+	assert.Equal(t, 1, metrics.ZiNi)
+}
+
+func TestMetricsOfSeparateOpenings(t *testing.T) {
+	metrics, err := newGameWithMines([]Option{Grid{5, 5}}, [2]int{2, 0}, [2]int{2, 1}, [2]int{2, 2}, [2]int{2, 3}, [2]int{2, 4}).Metrics()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, metrics.ThreeBV)
+	assert.Equal(t, 2, metrics.Openings)
+	assert.Equal(t, 0, metrics.IsolatedNumbers, "Every warning number borders an opening")
+	assert.Equal(t, 2, metrics.ZiNi)
+}
+
+func TestMetricsOfIsolatedNumbers(t *testing.T) {
+	mines := make([][2]int, 0)
+	for x := 0; x < 5; x += 2 {
+		for y := 0; y < 5; y += 2 {
+			mines = append(mines, [2]int{x, y})
+		}
+	}
+	metrics, err := newGameWithMines([]Option{Grid{5, 5}}, mines...).Metrics()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 16, metrics.ThreeBV)
+	assert.Equal(t, 0, metrics.Openings)
+	assert.Equal(t, 16, metrics.IsolatedNumbers)
+	assert.Equal(t, 10, metrics.ZiNi, "Chording the warning numbers between two mines saves clicks")
+}
+
+// The 1s around the mine are cleared by visiting one of them, flagging the mine
+// and chording twice, then visiting the last one.
+func TestMetricsZiNiChords(t *testing.T) {
+	metrics, err := newGameWithMines([]Option{Grid{3, 3}}, [2]int{1, 1}).Metrics()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 8, metrics.ThreeBV)
+	assert.Equal(t, 8, metrics.IsolatedNumbers)
+	assert.Equal(t, 5, metrics.ZiNi)
+}
+
+func TestMetricsOfPlayer(t *testing.T) {
+	game := newGameWithMines([]Option{Grid{3, 3}}, [2]int{1, 1})
+
+	metrics, _ := game.Metrics()
+	assert.Equal(t, 0, metrics.SolvedThreeBV)
+	assert.Equal(t, 0, metrics.Clicks)
+	assert.Equal(t, 0.0, metrics.Efficiency)
+
+	game.Visit(0, 1)
+	game.Flag(1, 1)
+	game.Chord(0, 1)
+	time.Sleep(10 * time.Millisecond)
+
+	metrics, _ = game.Metrics()
+	assert.Equal(t, 5, metrics.SolvedThreeBV)
+	assert.Equal(t, 3, metrics.Clicks)
+	assert.Equal(t, 5.0/3, metrics.Efficiency)
+	assert.True(t, metrics.ThreeBVPerSecond > 0)
+
+	game.Visit(2, 0)
+	game.Visit(2, 1)
+	game.Visit(2, 2)
+	assert.Equal(t, Won, game.State().Status)
+
+	metrics, _ = game.Metrics()
+	assert.Equal(t, metrics.ThreeBV, metrics.SolvedThreeBV)
+	assert.Equal(t, 6, metrics.Clicks)
+	assert.Equal(t, 8.0/6, metrics.Efficiency)
+	assert.Equal(t, float64(8)/game.State().Elapsed.Seconds(), metrics.ThreeBVPerSecond)
+}
+
+func TestMetricsBeforeMinesArePlaced(t *testing.T) {
+	game, _ := NewGame(Grid{5, 5}, WithSafeFirstVisit())
+	game.SetMineCount(5)
+	game.Play()
+
+	_, err := game.Metrics()
+	assert.Error(t, err)
+	assert.IsType(t, new(MinesNotPlacedError), err)
+
+	game.Visit(2, 2)
+	metrics, err := game.Metrics()
+	assert.NoError(t, err)
+	assert.True(t, metrics.ThreeBV > 0)
+
+	game, _ = NewGame()
+	_, err = game.Metrics()
+	assert.IsType(t, new(UnspecifiedGridError), err)
+}
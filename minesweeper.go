@@ -27,11 +27,23 @@ type Node uint8
 // Grid is the game's board size defined by its Grid.Width and Grid.Height
 type Grid struct{ Width, Height int }
 
+// Grid3D is the size of a three-dimensional board made of Grid3D.Depth layers
+// of Grid3D.Width and Grid3D.Height cells each. Like the Grid, it can be
+// supplied as an Option to the NewGame(...Option) function. The cells of the
+// board are located by their xyz-coordinates, where z is the cell's layer.
+type Grid3D struct{ Width, Height, Depth int }
+
 // Difficulty is the state of the game's difficulty.
 // Values of this type are minesweeper.Easy, minesweeper.Medium
 // and minesweeper.Hard
 type Difficulty uint8
 
+// CustomDifficulty is the preset of the game's board size and its exact number of
+// mines. It can be supplied as an Option to the NewGame(...Option) function in place
+// of the Grid and the Difficulty. Built-in presets are minesweeper.Beginner,
+// minesweeper.Intermediate and minesweeper.Expert
+type CustomDifficulty struct{ Width, Height, Mines int }
+
 // Event is a channel type used to receive the game's realtime event.
 // Particular values accepted to this channel are minesweeper.Win and
 // minesweeper.Lose
@@ -70,6 +82,19 @@ const (
 	// in the game with this difficulty would result to 50% of the
 	// total area of the board's size.
 	Hard
+
+	custom
+)
+
+var (
+	// Beginner is the classic preset of a 9x9 board with 10 mines.
+	Beginner = CustomDifficulty{Width: 9, Height: 9, Mines: 10}
+
+	// Intermediate is the classic preset of a 16x16 board with 40 mines.
+	Intermediate = CustomDifficulty{Width: 16, Height: 16, Mines: 40}
+
+	// Expert is the classic preset of a 30x16 board with 99 mines.
+	Expert = CustomDifficulty{Width: 30, Height: 16, Mines: 99}
 )
 
 const (
@@ -94,7 +119,9 @@ type Block struct {
 		x int
 		y int
 	}
-	visited, flagged bool
+	layer                        int
+	mines, flags                 int
+	visited, flagged, questioned bool
 }
 
 // Minesweeper is the main point of consumption and manipulation of the Minesweeper's
@@ -102,27 +129,59 @@ type Block struct {
 // a minesweeper game.
 //
 // Any instance derived by this interface is compatible for type casting to the
-// rendering.Tracker and visited.StoryTeller interfaces.
+// rendering.Tracker, rendering.Printer, rendering.Renderer and visited.StoryTeller
+// interfaces.
 type Minesweeper interface {
 	SetGrid(int, int) error
 
 	SetDifficulty(Difficulty) error
 
+	SetMineCount(int) error
+
 	Play() error
 
-	Flag(int, int)
+	Flag(int, int) error
 
 	Visit(int, int) ([]Block, error)
+
+	Chord(int, int) (ChordResult, error)
+
+	Flag3D(int, int, int) error
+
+	Visit3D(int, int, int) ([]Block, error)
+
+	Chord3D(int, int, int) (ChordResult, error)
+
+	Seed() int64
+
+	State() GameState
+
+	Subscribe() *Subscription
+
+	Undo() error
+
+	Redo() error
+
+	Snapshot() GameSnapshot
+
+	View() BoardView
+
+	Metrics() (BoardMetrics, error)
 }
 
 // NewGame creates a separate minesweeper instance. Unlike minesweeper.New,
 // this function creates a non-singleton instance. Functions of this package
 // such as Visit will become the methods of this instance.
-func NewGame(grid ...Grid) (Minesweeper, Event) {
+//
+// The game can be configured by supplying any Option such as the Grid or
+// WithSeed(int64). Only the first Grid supplied will be handled and the rest
+// will be ignored.
+func NewGame(options ...Option) (Minesweeper, Event) {
 	game := new(game)
+	game.seed = randomSeed()
 
-	if len(grid) > 0 {
-		game.SetGrid(grid[0].Width, grid[0].Height)
+	for _, option := range options {
+		option.apply(game)
 	}
 
 	game.Event = make(chan eventType, 1)
@@ -141,13 +200,13 @@ func NewGame(grid ...Grid) (Minesweeper, Event) {
 // independently listen for these events.
 //
 // As for this method's argument, this method appears to accept an arbitrary
-// number of trailing arguments of type Grid. It can only, however, handle only
-// one Grid instance and the rest of the arguments will be ignored. Although
+// number of trailing arguments of type Option. It can only, however, handle only
+// one Grid instance and the rest of the Grid arguments will be ignored. Although
 // supplying this Grid is also optional, you may encounter an UnspecifiedGridError
 // panic when calling the Play() method if the Grid is not supplied. You may
 // explicitly supply it by calling the SetGrid(int, int) method.
-func New(grid ...Grid) Event {
-	minesweeper, mainEvent := NewGame(grid...)
+func New(options ...Option) Event {
+	minesweeper, mainEvent := NewGame(options...)
 	singleton = minesweeper
 	return mainEvent
 }
@@ -172,26 +231,143 @@ func SetDifficulty(difficulty Difficulty) error {
 	return singleton.SetDifficulty(difficulty)
 }
 
+// SetMineCount sets the exact number of mines of the game in place of the
+// difficulty. Whichever of this method and SetDifficulty(Difficulty) is called
+// last is used. An error will return if the Play() method has already been
+// called.
+//
+// The number of mines is validated when the Play() method is called, which
+// returns an InvalidMineCountError if the mines do not fit the board's size.
+func SetMineCount(mines int) error {
+	return singleton.SetMineCount(mines)
+}
+
 // Play allows the game to setup all the mines in place randomly. The
-// placement is non-deterministic since the implementation uses the
-// "crypto/rand" package.
+// placement is derived from the game's seed, which is drawn from the
+// "crypto/rand" package unless the WithSeed(int64) option is supplied. When
+// the game is created with the WithSafeFirstVisit() or WithSafeOpening()
+// option, the mines are placed on the first Visit(int, int) call instead.
 //
 // An error will return when this method is called twice or more.
 //
 // More importantly, Grid size and Difficulty must be specified, otherwise,
 // you will encounter an UnspecifiedGridError and UnspecifiedDifficultyError,
-// respectively.
+// respectively. An InvalidMineCountError will return if the number of mines
+// set by SetMineCount(int) leaves no cell free of mines and an
+// OddWrapAroundWidthError will return if a hexagonal board of odd width is
+// created with the WithWrapAround() option. A NoGuessMultiMineError will return
+// if the WithNoGuess() option is supplied with WithMaxMinesPerCell(int).
 func Play() error {
 	return singleton.Play()
 }
 
+// Seed returns the seed used to place the mines of the game. A game created
+// without the WithSeed(int64) option is given a random seed, so supplying
+// this value to a new game with the same Grid and Difficulty recreates the
+// same board.
+func Seed() int64 {
+	return singleton.Seed()
+}
+
+// State returns the snapshot of the game's progress such as whether the game is
+// ongoing, won or lost, the number of mines remaining, the number of non-mine cells
+// left to be visited, the number of moves made and the elapsed time of the game.
+// Unlike the game's event channel, the state can be queried at any time.
+func State() GameState {
+	return singleton.State()
+}
+
+// Subscribe creates a Subscription that receives every event of the game from
+// this point onwards, such as cells being revealed or flagged and the game being
+// won or lost. Any number of subscriptions can be created and each of them
+// receives the events in the order they happened. Call the Close() method of the
+// subscription once the events are no longer needed.
+func Subscribe() *Subscription {
+	return singleton.Subscribe()
+}
+
 // Flag marks the cell, according to the coordinates supplied in the
 // method argument, as flagged. When a particular cell is flagged, the
 // cell in question will prevent from being handled by the game when
 // the Visit(int, int) method with the same coordinate of the cell in
 // question is called.
-func Flag(x int, y int) {
-	singleton.Flag(x, y)
+//
+// When the game is created with the WithQuestionMarks() option, calling this
+// method on the same cell cycles the cell from unmarked to flagged, from
+// flagged to question-marked and from question-marked to unmarked. Unlike a
+// flagged cell, a question-marked cell can still be visited. When the game is
+// created with the WithMaxMinesPerCell(int) option, flagging a flagged cell
+// first raises the number of mines carried by its flag.
+//
+// An OutOfBoundsError will return if the coordinates are outside the board and a
+// GameOverError will return once the game is won or lost.
+func Flag(x int, y int) error {
+	return singleton.Flag(x, y)
+}
+
+// Chord visits all unflagged neighboring cells of an already visited cell with a
+// warning number, according to the xy-coordinates of the argument, when the number
+// of its flagged neighboring cells equals its warning number. The returned
+// ChordResult reports whether the chord is accepted, all cells revealed by the
+// chord and, when a neighboring cell is wrongly flagged, every mine hit by the
+// chord along with the wrong flags.
+//
+// An ExplodedError will return if a mine is hit, with the coordinates of the
+// first mine hit. Like visiting a mine, the game ends. Calling this method on a
+// cell that is not a visited warning number has no effect on the game and the
+// chord is not accepted. An OutOfBoundsError will return if the coordinates
+// are outside the board and a GameOverError will return once the game is won
+// or lost.
+//
+// Visiting an already visited cell with a warning number by the Visit(int, int)
+// method is the same as calling this method.
+func Chord(x int, y int) (ChordResult, error) {
+	return singleton.Chord(x, y)
+}
+
+// Undo takes back the last move made by the Visit(int, int) or Flag(int, int)
+// method, including a move that visited a mine. Every cell revealed by the move,
+// such as the cells automatically visited around a cell with no warning number,
+// becomes unvisited again, and the move is no longer counted by the Moves of the
+// game's state. A NothingToUndoError will return if there is no move to take
+// back.
+//
+// A game created with the WithRanking() option becomes unranked once this method
+// is called.
+func Undo() error {
+	return singleton.Undo()
+}
+
+// Redo makes the last move taken back by the Undo() method again. Making a new
+// move discards the moves that can be redone. A NothingToRedoError will return
+// if there is no move to make again.
+func Redo() error {
+	return singleton.Redo()
+}
+
+// Snapshot saves the state of the game, such as its board, the visited and
+// flagged cells, the player's history and the timer. The game can be loaded
+// again from the returned snapshot by the LoadGame(GameSnapshot) function.
+func Snapshot() GameSnapshot {
+	return singleton.Snapshot()
+}
+
+// View returns the board as observed by the player. Each cell of the board is
+// either hidden, flagged or revealed with its warning number. Once the game is
+// lost, the mines and the wrongly flagged cells are revealed as well. Unlike the
+// BombLocations() and HintLocations() methods of the rendering.Tracker interface,
+// the view is safe to share with the player.
+func View() BoardView {
+	return singleton.View()
+}
+
+// Metrics analyzes the board's mine layout and the player's performance. The
+// returned BoardMetrics reports the 3BV of the board, its openings and isolated
+// numbers, the ZiNi estimate, and the 3BV per second and the efficiency derived
+// from the player's history. The MinesNotPlacedError is returned until the
+// mines are placed.
+func Metrics() (BoardMetrics, error) {
+	return singleton.Metrics()
 }
 
 // Visit visits a particular cell according to the xy-coordinates of the argument
@@ -227,7 +403,38 @@ func Flag(x int, y int) {
 // treat the called method as if nothing was called at all.
 //
 // The last Visit() method call with the last non-mine cell will trigger
-// the Win event. The game ends eventually.
+// the Win event. The game ends eventually. Both the Win and Lose events are
+// decided before this method returns, so the State() method reports the
+// game as won or lost right after the call.
+//
+// An OutOfBoundsError will return if the coordinates are outside the board, a
+// GameNotStartedError will return before the Play() method is called and a
+// GameOverError will return once the game is won or lost, leaving the game
+// unchanged. An UnspecifiedGridError or UnspecifiedDifficultyError will return
+// if the Grid or the Difficulty is not set.
 func Visit(x int, y int) ([]Block, error) {
 	return singleton.Visit(x, y)
 }
+
+// Flag3D flags the cell at the xyz-coordinates of a board created with the
+// Grid3D option, where z is the cell's layer. It behaves like the
+// Flag(int, int) method, which flags the cell in the first layer.
+func Flag3D(x, y, z int) error {
+	return singleton.Flag3D(x, y, z)
+}
+
+// Visit3D visits the cell at the xyz-coordinates of a board created with the
+// Grid3D option, where z is the cell's layer. It behaves like the
+// Visit(int, int) method, which visits the cell in the first layer. Visiting
+// a cell with no warning number also visits its neighboring cells in the
+// adjacent layers.
+func Visit3D(x, y, z int) ([]Block, error) {
+	return singleton.Visit3D(x, y, z)
+}
+
+// Chord3D chords the cell at the xyz-coordinates of a board created with the
+// Grid3D option, where z is the cell's layer. It behaves like the
+// Chord(int, int) method, which chords the cell in the first layer.
+func Chord3D(x, y, z int) (ChordResult, error) {
+	return singleton.Chord3D(x, y, z)
+}
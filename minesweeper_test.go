@@ -49,3 +49,105 @@ func TestFunctionVisit(t *testing.T) {
 	Visit(2, 2)
 	assert.True(t, singleton.(*game).blocks[2][2].visited)
 }
+
+func TestFunctionSeed(t *testing.T) {
+	New(WithSeed(7))
+	assert.Equal(t, int64(7), Seed())
+}
+
+func TestFunctionSetMineCount(t *testing.T) {
+	New(Grid{4, 5})
+	SetMineCount(3)
+	Play()
+	assert.Equal(t, 3, singleton.(*game).totalBombs())
+}
+
+func TestFunctionState(t *testing.T) {
+	New(Grid{4, 5})
+	SetDifficulty(Easy)
+	Play()
+	assert.Equal(t, Ongoing, State().Status)
+}
+
+func TestFunctionSubscribe(t *testing.T) {
+	New(Grid{4, 5})
+	SetDifficulty(Easy)
+	subscription := Subscribe()
+	defer subscription.Close()
+	Play()
+	assert.Equal(t, GameStarted, (<-subscription.Events()).Kind)
+}
+
+func TestFunctionUndoAndRedo(t *testing.T) {
+	New(Grid{4, 5})
+	SetDifficulty(Easy)
+	Play()
+	Flag(0, 0)
+	Undo()
+	assert.False(t, singleton.(*game).blocks[0][0].flagged)
+	Redo()
+	assert.True(t, singleton.(*game).blocks[0][0].flagged)
+}
+
+func TestFunctionSnapshot(t *testing.T) {
+	New(Grid{4, 5})
+	SetDifficulty(Easy)
+	Play()
+	assert.Equal(t, &Grid{4, 5}, Snapshot().Grid)
+}
+
+func TestFunctionView(t *testing.T) {
+	New(Grid{4, 5})
+	SetDifficulty(Easy)
+	Play()
+	assert.Equal(t, 4, len(View()))
+}
+
+func TestFunctionChord(t *testing.T) {
+	New(Grid{4, 5})
+	SetDifficulty(Easy)
+	Play()
+	result, err := Chord(0, 0)
+	assert.NoError(t, err)
+	assert.False(t, result.Accepted)
+}
+
+func TestFunction3D(t *testing.T) {
+	New(Grid3D{3, 3, 3})
+	SetMineCount(1)
+	Play()
+
+	game := singleton.(*game)
+	game.clearMines()
+	game.block(0, 0, 0).addMine()
+	tallyHints(game)
+	game.minesPlaced = true
+
+	assert.NoError(t, Flag3D(0, 0, 0))
+	assert.True(t, game.block(0, 0, 0).flagged)
+
+	blocks, err := Visit3D(1, 1, 1)
+	assert.NoError(t, err)
+	assert.Len(t, blocks, 1)
+
+	result, err := Chord3D(1, 1, 1)
+	assert.NoError(t, err)
+	assert.True(t, result.Accepted)
+	assert.Equal(t, Won, State().Status)
+}
+
+func TestFunctionMetrics(t *testing.T) {
+	New(Grid{3, 3})
+	SetMineCount(1)
+	Play()
+
+	game := singleton.(*game)
+	game.clearMines()
+	game.blocks[1][1].addMine()
+	tallyHints(game)
+	game.minesPlaced = true
+
+	metrics, err := Metrics()
+	assert.NoError(t, err)
+	assert.Equal(t, 8, metrics.ThreeBV)
+}
@@ -0,0 +1,163 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	mathrand "math/rand"
+)
+
+// Option configures a game instance when supplied to the NewGame(...Option) or
+// New(...Option) function. A Grid is itself an Option, so the board's size can
+// be supplied alongside any other options.
+type Option interface {
+	apply(*game)
+}
+
+type optionFunc func(*game)
+
+func (option optionFunc) apply(game *game) {
+	option(game)
+}
+
+func (grid Grid) apply(game *game) {
+	game.SetGrid(grid.Width, grid.Height)
+}
+
+func (grid Grid3D) apply(game *game) {
+	if game.Grid == nil {
+		game.depth = grid.Depth
+	}
+	game.SetGrid(grid.Width, grid.Height)
+}
+
+func (preset CustomDifficulty) apply(game *game) {
+	game.SetGrid(preset.Width, preset.Height)
+	game.SetMineCount(preset.Mines)
+}
+
+// WithSeed makes the placement of mines deterministic. Two games created with
+// the same seed, Grid and Difficulty will always place their mines at identical
+// locations once the Play() method is called.
+func WithSeed(seed int64) Option {
+	return optionFunc(func(game *game) {
+		game.seed = seed
+	})
+}
+
+type firstVisitSafety uint8
+
+const (
+	unsafeFirstVisit firstVisitSafety = iota
+	safeFirstVisit
+	safeOpening
+	noGuessOpening
+)
+
+// WithSafeFirstVisit defers the placement of mines until the first Visit(int, int)
+// method is called, guaranteeing that the first visited cell is never a mine.
+func WithSafeFirstVisit() Option {
+	return optionFunc(func(game *game) {
+		game.firstVisitSafety = safeFirstVisit
+	})
+}
+
+// WithSafeOpening defers the placement of mines until the first Visit(int, int)
+// method is called, guaranteeing that the first visited cell and all of its
+// neighboring cells are free of mines. Since the first visited cell will have
+// no warning number, the visit will always reveal an opening. If the board is
+// too small to fit all the mines around the opening, only the first visited cell
+// is guaranteed to be free of mines.
+func WithSafeOpening() Option {
+	return optionFunc(func(game *game) {
+		game.firstVisitSafety = safeOpening
+	})
+}
+
+// WithNoGuess defers the placement of mines until the first Visit(int, int)
+// method is called, like WithSafeOpening(), and only accepts a board that can
+// be entirely solved by logic from the first visited cell. Every candidate board
+// is verified by a built-in constraint solver and regenerated until it passes.
+//
+// The Visit(int, int) method returns an UnsolvableBoardError if no such board is
+// found after a number of attempts, which is likely for the Hard difficulty.
+func WithNoGuess() Option {
+	return optionFunc(func(game *game) {
+		game.firstVisitSafety = noGuessOpening
+	})
+}
+
+// WithQuestionMarks enables the classic marking cycle of the Flag(int, int)
+// method. Flagging a flagged cell marks it with a question mark and flagging a
+// question-marked cell removes its mark.
+func WithQuestionMarks() Option {
+	return optionFunc(func(game *game) {
+		game.questionMarks = true
+	})
+}
+
+// WithMaxMinesPerCell allows a cell to hold up to the number of mines, as in the
+// "Multimines" variant. The warning number of a cell counts every mine of its
+// neighboring cells, and flagging a flagged cell raises the number of mines
+// carried by its flag, up to the maximum, before the flag is removed. The game
+// is won once every cell free of mines is visited.
+//
+// The number of mines set by the SetMineCount(int) method or the Difficulty is
+// the total number of mines, so fewer cells hold a mine. The WithNoGuess()
+// option does not support cells holding several mines, so the Play() method
+// returns a NoGuessMultiMineError when both options are supplied.
+func WithMaxMinesPerCell(max int) Option {
+	return optionFunc(func(game *game) {
+		game.maxMines = max
+	})
+}
+
+func (game *game) maxMinesPerCell() int {
+	if game.maxMines < 1 {
+		return 1
+	}
+	return game.maxMines
+}
+
+// WithRanking marks the game as ranked, as reported by the State() method, until
+// a move is taken back by the Undo() method.
+func WithRanking() Option {
+	return optionFunc(func(game *game) {
+		game.ranked = true
+	})
+}
+
+func randomSeed() int64 {
+	var seed int64
+	binary.Read(rand.Reader, binary.LittleEndian, &seed)
+	return seed
+}
+
+func (game *game) Seed() int64 {
+	return game.seed
+}
+
+func (game *game) randomNumber(max int) int {
+	if game.random == nil {
+		game.random = mathrand.New(mathrand.NewSource(game.seed))
+	}
+	return game.random.Intn(max)
+}
@@ -0,0 +1,267 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import (
+	"testing"
+
+	"github.com/rrborja/minesweeper/rendering"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameWithSameSeedPlacesSameBombs(t *testing.T) {
+	first, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithSeed(1234))
+	first.SetDifficulty(Medium)
+	first.Play()
+
+	second, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithSeed(1234))
+	second.SetDifficulty(Medium)
+	second.Play()
+
+	assert.Equal(t, first.(*game).blocks, second.(*game).blocks)
+}
+
+func TestGameWithDifferentSeedPlacesDifferentBombs(t *testing.T) {
+	first, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithSeed(1234))
+	first.SetDifficulty(Medium)
+	first.Play()
+
+	second, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithSeed(4321))
+	second.SetDifficulty(Medium)
+	second.Play()
+
+	assert.NotEqual(t, first.(*game).blocks, second.(*game).blocks)
+}
+
+func TestGame_Seed(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithSeed(42))
+	assert.Equal(t, int64(42), minesweeper.Seed())
+}
+
+func TestUnseededGameCanBeRecreated(t *testing.T) {
+	original, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight})
+	original.SetDifficulty(Hard)
+	original.Play()
+
+	recreated, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithSeed(original.Seed()))
+	recreated.SetDifficulty(Hard)
+	recreated.Play()
+
+	assert.Equal(t, original.(*game).blocks, recreated.(*game).blocks)
+}
+
+func TestOnlyFirstGridOptionIsHandled(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{3, 4}, Grid{5, 6})
+	assert.Equal(t, &Grid{3, 4}, minesweeper.(*game).Grid)
+}
+
+func TestSafeFirstVisitDefersBombPlacement(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithSafeFirstVisit())
+	minesweeper.SetDifficulty(Hard)
+	minesweeper.Play()
+
+	assert.Empty(t, minesweeper.(rendering.Tracker).BombLocations())
+}
+
+func TestSafeFirstVisitNeverExplodes(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithSafeFirstVisit())
+		minesweeper.SetDifficulty(Hard)
+		minesweeper.Play()
+
+		game := minesweeper.(*game)
+		x, y := coordinates.Intn(sampleGridWidth), coordinates.Intn(sampleGridHeight)
+		_, err := minesweeper.Visit(x, y)
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, Bomb, game.blocks[x][y].Node)
+		assert.Equal(t, game.totalBombs(), len(game.BombLocations()))
+	}
+}
+
+func TestSafeOpeningRevealsAnOpening(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithSafeOpening())
+		minesweeper.SetDifficulty(Medium)
+		minesweeper.Play()
+
+		game := minesweeper.(*game)
+		x, y := coordinates.Intn(sampleGridWidth), coordinates.Intn(sampleGridHeight)
+		blocks, err := minesweeper.Visit(x, y)
+		assert.NoError(t, err)
+
+		assert.Equal(t, Unknown, game.blocks[x][y].Node)
+		game.traverseAdjacentCells(x, y, func(cell *Block) {
+			assert.NotEqual(t, Bomb, cell.Node)
+			assert.True(t, cell.visited)
+		})
+		assert.True(t, len(blocks) > 1)
+	}
+}
+
+func TestSafeOpeningFallsBackToSafeCellOnCrowdedBoard(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{3, 3}, WithSafeOpening())
+	minesweeper.SetDifficulty(Hard)
+	minesweeper.Play()
+
+	_, err := minesweeper.Visit(1, 1)
+	assert.NoError(t, err)
+
+	game := minesweeper.(*game)
+	assert.Equal(t, game.totalBombs(), len(game.BombLocations()))
+}
+
+func TestSafeFirstVisitWithSameSeedAndVisitPlacesSameBombs(t *testing.T) {
+	first, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithSeed(99), WithSafeOpening())
+	first.SetDifficulty(Medium)
+	first.Play()
+	first.Visit(4, 4)
+
+	second, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithSeed(99), WithSafeOpening())
+	second.SetDifficulty(Medium)
+	second.Play()
+	second.Visit(4, 4)
+
+	assert.Equal(t, first.(*game).blocks, second.(*game).blocks)
+}
+
+// Creates a started 4x4 board allowing 3 mines per cell with the given number
+// of mines at the xy-coordinates.
+func newMultiMineGame(mines map[[2]int]int) Minesweeper {
+	locations := make([][2]int, 0)
+	for location, count := range mines {
+		for i := 0; i < count; i++ {
+			locations = append(locations, location)
+		}
+	}
+	return newGameWithMines([]Option{Grid{4, 4}, WithMaxMinesPerCell(3)}, locations...)
+}
+
+func TestMaxMinesPerCellPlacesAllMines(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{5, 5}, WithMaxMinesPerCell(3), WithSeed(3))
+	minesweeper.SetMineCount(60)
+	assert.NoError(t, minesweeper.Play())
+
+	game := minesweeper.(*game)
+	var mines, cells int
+	game.iterateBlocksWhen(Bomb, func(block *Block) {
+		assert.True(t, block.Mines() >= 1 && block.Mines() <= 3)
+		mines += block.Mines()
+		cells++
+	})
+	assert.Equal(t, 60, mines)
+	assert.True(t, cells >= 20, "60 mines of up to 3 per cell need at least 20 cells")
+	assert.Equal(t, game.area()-cells, game.totalNonBombs())
+	assert.Equal(t, game.area()-cells, minesweeper.State().UnrevealedSafeCells)
+}
+
+func TestMaxMinesPerCellRejectsTooManyMines(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{3, 3}, WithMaxMinesPerCell(2))
+	minesweeper.SetMineCount(17)
+	assert.Equal(t, &InvalidMineCountError{mines: 17, grid: Grid{3, 3}, maxMinesPerCell: 2}, minesweeper.Play())
+
+	minesweeper, _ = NewGame(Grid{3, 3}, WithMaxMinesPerCell(2))
+	minesweeper.SetMineCount(16)
+	assert.NoError(t, minesweeper.Play())
+}
+
+func TestMaxMinesPerCellRejectsNoGuess(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{9, 9}, WithMaxMinesPerCell(3), WithNoGuess())
+	minesweeper.SetDifficulty(Easy)
+	assert.Equal(t, &NoGuessMultiMineError{maxMinesPerCell: 3}, minesweeper.Play())
+	assert.Equal(t, NotStarted, minesweeper.State().Status)
+
+	minesweeper, _ = NewGame(Grid{9, 9}, WithMaxMinesPerCell(1), WithNoGuess())
+	minesweeper.SetDifficulty(Easy)
+	assert.NoError(t, minesweeper.Play())
+}
+
+func TestMaxMinesPerCellHints(t *testing.T) {
+	minesweeper := newMultiMineGame(map[[2]int]int{{0, 0}: 3, {0, 2}: 2})
+	game := minesweeper.(*game)
+
+	assert.Equal(t, 3, game.blocks[1][0].Value)
+	assert.Equal(t, 5, game.blocks[0][1].Value)
+	assert.Equal(t, 5, game.blocks[1][1].Value)
+	assert.Equal(t, 2, game.blocks[1][3].Value)
+}
+
+func TestMaxMinesPerCellFlagCarriesNumber(t *testing.T) {
+	minesweeper := newMultiMineGame(map[[2]int]int{{0, 0}: 3})
+	game := minesweeper.(*game)
+
+	for flags := 1; flags <= 3; flags++ {
+		minesweeper.Flag(0, 0)
+		assert.True(t, game.blocks[0][0].Flagged())
+		assert.Equal(t, flags, game.blocks[0][0].FlaggedMines())
+		assert.Equal(t, 3-flags, minesweeper.State().MinesRemaining)
+	}
+	assert.Equal(t, CellState(Flagged), minesweeper.View()[0][0].State)
+	assert.Equal(t, 3, minesweeper.View()[0][0].Value)
+
+	minesweeper.Flag(0, 0)
+	assert.False(t, game.blocks[0][0].Flagged())
+	assert.Equal(t, 0, game.blocks[0][0].FlaggedMines())
+	assert.Equal(t, 3, minesweeper.State().MinesRemaining)
+
+	minesweeper.Flag(0, 0)
+	minesweeper.Flag(0, 0)
+	minesweeper.Undo()
+	assert.Equal(t, 1, game.blocks[0][0].FlaggedMines())
+}
+
+func TestMaxMinesPerCellChordCountsFlaggedMines(t *testing.T) {
+	minesweeper := newMultiMineGame(map[[2]int]int{{0, 0}: 2})
+	minesweeper.Visit(1, 1)
+	minesweeper.Flag(0, 0)
+
+	result, err := minesweeper.Chord(1, 1)
+	assert.NoError(t, err)
+	assert.False(t, result.Accepted, "The flag carries one of the two mines")
+
+	minesweeper.Flag(0, 0)
+	result, err = minesweeper.Chord(1, 1)
+	assert.NoError(t, err)
+	assert.True(t, result.Accepted)
+	assert.Equal(t, Won, minesweeper.State().Status, "The game is won once every cell free of mines is visited")
+}
+
+func TestMaxMinesPerCellExplosion(t *testing.T) {
+	minesweeper := newMultiMineGame(map[[2]int]int{{0, 0}: 2, {3, 3}: 1})
+
+	_, err := minesweeper.Visit(0, 0)
+	assert.Error(t, err)
+	assert.Equal(t, Lost, minesweeper.State().Status)
+	assert.Equal(t, Cell{State: ExplodedMine, Value: 2}, minesweeper.View()[0][0])
+	assert.Equal(t, Cell{State: RevealedMine, Value: 1}, minesweeper.View()[3][3])
+}
+
+func TestSnapshotKeepsMultipleMinesPerCell(t *testing.T) {
+	minesweeper := newMultiMineGame(map[[2]int]int{{0, 0}: 3, {2, 2}: 1})
+	minesweeper.Flag(0, 0)
+	minesweeper.Flag(0, 0)
+	minesweeper.Visit(3, 0)
+
+	loaded, _, err := LoadGame(minesweeper.Snapshot())
+	assert.NoError(t, err)
+	assert.Equal(t, minesweeper.(*game).blocks, loaded.(*game).blocks)
+	assert.Equal(t, minesweeper.State().MinesRemaining, loaded.State().MinesRemaining)
+	assert.Equal(t, minesweeper.State().UnrevealedSafeCells, loaded.State().UnrevealedSafeCells)
+}
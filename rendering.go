@@ -21,6 +21,8 @@ package minesweeper
 
 import (
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 
 	"github.com/rrborja/minesweeper/rendering"
@@ -29,10 +31,14 @@ import (
 
 type recordedActions struct {
 	*visited.History
+	undoable, redoable []*move
+
+	// changes are the marks of the blocks changed by the move in progress
+	changes []change
 }
 
 func (game *game) BombLocations() []rendering.Position {
-	bombPlacements := make([]rendering.Position, int(float32(game.Height*game.Width)*game.difficultyMultiplier))
+	bombPlacements := make([]rendering.Position, game.totalBombs())
 
 	var counter int
 	game.iterateBlocks(func(block *Block) bool {
@@ -43,7 +49,7 @@ func (game *game) BombLocations() []rendering.Position {
 		return true
 	})
 
-	return bombPlacements
+	return bombPlacements[:counter]
 }
 
 // Not recommended to call this function until a new update to improve the performance of this method
@@ -68,46 +74,173 @@ func (game *game) LastAction() visited.Record {
 	return game.recordedActions.History.Record
 }
 
+// Print prints the solution of the board. The layers of a board created with
+// the Grid3D option are printed one after another, separated by a blank line.
+// Use the Render method to draw the board as observed by the player instead.
 func (game *game) Print() {
 	bombs := game.BombLocations()
 	hints := game.HintLocations()
 
-	star := '*'
-
-	var board = make([][]*rune, game.Width)
+	var board = make([][]string, game.Width)
 	for i := range board {
-		board[i] = make([]*rune, game.Height)
+		board[i] = make([]string, game.Height*game.layers())
 	}
 
 	for _, bomb := range bombs {
 		x := bomb.X()
-		y := bomb.Y()
-		board[x][y] = &star
+		y := bomb.Z()*game.Height + bomb.Y()
+		board[x][y] = "*"
 	}
 
+	width := 1
 	for _, hint := range hints {
 		x := hint.X()
-		y := hint.Y()
-		value := rune(hint.(Block).Value + 48)
-		board[x][y] = &value
+		y := hint.Z()*game.Height + hint.Y()
+		board[x][y] = strconv.Itoa(hint.(Block).Value)
+		if len(board[x][y]) > width {
+			width = len(board[x][y])
+		}
+	}
+
+	game.iterateBlocks(func(block *Block) bool {
+		if block.questioned {
+			board[block.X()][game.row(block)] = "?"
+		}
+		return true
+	})
+
+	var layers = make([]string, game.layers())
+	for z := range layers {
+		var boardLayout = make([]string, game.Width)
+		for i, column := range board {
+			row := column[z*game.Height : (z+1)*game.Height]
+			var cellLayout strings.Builder
+			for _, cell := range row {
+				if cell == "" {
+					cell = "."
+				}
+				cellLayout.WriteString(strings.Repeat(" ", width-len(cell)) + cell + " ")
+			}
+			boardLayout[i] = cellLayout.String()
+			if game.topology == Hexagonal && i%2 == 1 {
+				boardLayout[i] = " " + boardLayout[i]
+			}
+		}
+		layers[z] = strings.Join(boardLayout, "\n")
+	}
+
+	fmt.Println(strings.Join(layers, "\n\n"))
+}
+
+// symbols are the characters drawn by the Render method for every state of a cell
+// other than the revealed numbers
+var symbols = map[CellState]string{
+	Hidden:         "#",
+	Flagged:        "F",
+	QuestionMarked: "?",
+	RevealedBlank:  ".",
+	ExplodedMine:   "@",
+	RevealedMine:   "*",
+	WrongFlag:      "X",
+}
+
+// Render draws the board as observed by the player, one line for each
+// x-coordinate like the Print() method. A hidden cell is drawn as '#', a flagged
+// cell as 'F', a question-marked cell as '?', a revealed cell with no warning
+// number as '.' and a revealed cell with a warning number as its number. Once
+// the game is lost, the visited mine is drawn as '@', the other mines as '*' and
+// the wrongly flagged cells as 'X'.
+//
+// The rendering.RevealAll() option draws every hidden cell as if it is
+// revealed, and the rendering.WithLabels() option draws the coordinates of the
+// cells. The layers of a board created with the Grid3D option are drawn one
+// after another, separated by a blank line.
+func (game *game) Render(writer io.Writer, options ...rendering.Option) error {
+	if game.Grid == nil {
+		return new(UnspecifiedGridError)
 	}
 
-	var boardLayout = make([]string, game.Width)
-	for i, row := range board {
-		cellLayout := make([]rune, (game.Height * 2))
-		for j, cell := range row {
-			switch cell {
-			case nil:
-				cellLayout[j*2] = '.'
+	if game.Mutex != nil {
+		game.Lock()
+		defer game.Unlock()
+	}
+
+	settings := rendering.Configure(options...)
+	view := game.view()
+	if settings.RevealAll {
+		game.iterateBlocks(func(block *Block) bool {
+			cell := &view[block.X()][game.row(block)]
+			switch {
+			case cell.State == Flagged && block.Node != Bomb:
+				cell.State = WrongFlag
+			case cell.State != Hidden && cell.State != QuestionMarked:
+			case block.Node == Bomb:
+				*cell = Cell{State: RevealedMine, Value: block.Mines()}
+			case block.Node == Number:
+				*cell = Cell{State: RevealedNumber, Value: block.Value}
 			default:
-				cellLayout[j*2] = *cell
+				*cell = Cell{State: RevealedBlank}
+			}
+			return true
+		})
+	}
+
+	width := 1
+	if settings.Labels {
+		width = len(strconv.Itoa(game.Height - 1))
+	}
+	cells := make([][]string, game.Width)
+	for x := range cells {
+		cells[x] = make([]string, len(view[x]))
+		for y, cell := range view[x] {
+			symbol, ok := symbols[cell.State]
+			if !ok {
+				symbol = strconv.Itoa(cell.Value)
+			}
+			cells[x][y] = symbol
+			if len(symbol) > width {
+				width = len(symbol)
+			}
+		}
+	}
+
+	labelWidth := len(strconv.Itoa(game.Width - 1))
+	pad := func(text string, width int) string {
+		return strings.Repeat(" ", width-len(text)) + text
+	}
+
+	layers := make([]string, game.layers())
+	for z := range layers {
+		lines := make([]string, 0, game.Width+2)
+		if settings.Labels {
+			if game.layers() > 1 {
+				lines = append(lines, fmt.Sprintf("Layer %v", z))
+			}
+			header := make([]string, game.Height)
+			for y := range header {
+				header[y] = pad(strconv.Itoa(y), width)
+			}
+			lines = append(lines, strings.Repeat(" ", labelWidth+1)+strings.Join(header, " "))
+		}
+		for x := range cells {
+			row := make([]string, game.Height)
+			for y := range row {
+				row[y] = pad(cells[x][z*game.Height+y], width)
+			}
+			line := strings.Join(row, " ")
+			if game.topology == Hexagonal && x%2 == 1 {
+				line = " " + line
+			}
+			if settings.Labels {
+				line = pad(strconv.Itoa(x), labelWidth) + " " + line
 			}
-			cellLayout[j*2+1] = ' '
+			lines = append(lines, line)
 		}
-		boardLayout[i] = string(cellLayout)
+		layers[z] = strings.Join(lines, "\n")
 	}
 
-	fmt.Println(strings.Join(boardLayout, "\n"))
+	_, err := io.WriteString(writer, strings.Join(layers, "\n\n")+"\n")
+	return err
 }
 
 func (game *recordedActions) add(record visited.Record) {
@@ -19,6 +19,8 @@
 
 package rendering
 
+import "io"
+
 // Position is used to interface the cell's xy-coordinates used for this package
 type Position interface {
 	// X returns the x-coordinate of the cell in the grid
@@ -26,6 +28,10 @@ type Position interface {
 
 	// Y returns the y-coordinate of the cell in the grid
 	Y() int
+
+	// Z returns the layer of the cell in a three-dimensional grid, which is
+	// zero for a flat grid
+	Z() int
 }
 
 // Tracker is used to interface the instance of the Minesweeper game to retrieve
@@ -43,3 +49,47 @@ type Tracker interface {
 type Printer interface {
 	Print()
 }
+
+// Renderer is used to draw the game's board as observed by the player
+type Renderer interface {
+	// Render writes the board to the writer, configured by the options such as
+	// RevealAll() and WithLabels()
+	Render(io.Writer, ...Option) error
+}
+
+// Option configures the drawing of the board by the Render method
+type Option func(*Settings)
+
+// Settings are the configuration of the Render method collected from its options
+type Settings struct {
+	// RevealAll draws the solution of the board over the player's view
+	RevealAll bool
+
+	// Labels draws the coordinates of the cells along the edges of the board
+	Labels bool
+}
+
+// RevealAll draws the content of every cell, showing the mines and the warning
+// numbers the player has not found yet
+func RevealAll() Option {
+	return func(settings *Settings) {
+		settings.RevealAll = true
+	}
+}
+
+// WithLabels draws the y-coordinates above the board and the x-coordinate in
+// front of every line of the board
+func WithLabels() Option {
+	return func(settings *Settings) {
+		settings.Labels = true
+	}
+}
+
+// Configure collects the settings of the options
+func Configure(options ...Option) Settings {
+	var settings Settings
+	for _, option := range options {
+		option(&settings)
+	}
+	return settings
+}
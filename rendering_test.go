@@ -20,7 +20,9 @@
 package minesweeper
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"strings"
@@ -115,8 +117,8 @@ func TestRecentPlayersMove(t *testing.T) {
 
 	maxMoves := 10
 	for i := 0; i < maxMoves; i++ {
-		randomX := randomNumber(sampleGridWidth)
-		randomY := randomNumber(sampleGridHeight)
+		randomX := coordinates.Intn(sampleGridWidth)
+		randomY := coordinates.Intn(sampleGridHeight)
 		blocks, err := minesweeper.Visit(randomX, randomY)
 
 		if len(blocks) == 0 { // Either already visited block or flagged block
@@ -210,3 +212,136 @@ func TestGamePrintBoard(t *testing.T) {
 	assert.Equal(t, string(expected), string(actual))
 
 }
+
+func TestGamePrintQuestionMarkedCell(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithQuestionMarks())
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	minesweeper.Flag(3, 6)
+	minesweeper.Flag(3, 6)
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	minesweeper.(rendering.Printer).Print()
+
+	w.Close()
+	actual, _ := ioutil.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	boardLayout := strings.Split(string(actual), "\n")
+	assert.Equal(t, byte('?'), boardLayout[3][6*2])
+}
+
+func TestGamePrintLayers(t *testing.T) {
+	// The mine is at the xyz-coordinates (0, 0, 1)
+	minesweeper := newGameWithMines([]Option{Grid3D{2, 3, 2}}, [2]int{0, 3})
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	minesweeper.(rendering.Printer).Print()
+
+	w.Close()
+	actual, _ := ioutil.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.Equal(t, "1 1 . \n1 1 . \n\n* 1 . \n1 1 . \n", string(actual))
+}
+
+func TestGamePrintNumbersAboveNine(t *testing.T) {
+	minesweeper := newGameWithMines([]Option{Grid{3, 3}, WithMaxMinesPerCell(3)},
+		[2]int{0, 0}, [2]int{0, 0}, [2]int{0, 0},
+		[2]int{0, 1}, [2]int{0, 1}, [2]int{0, 1},
+		[2]int{0, 2}, [2]int{0, 2}, [2]int{0, 2},
+		[2]int{1, 0})
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	minesweeper.(rendering.Printer).Print()
+
+	w.Close()
+	actual, _ := ioutil.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.Equal(t, " *  *  * \n * 10  6 \n 1  1  . \n", string(actual))
+}
+
+func newPlayerViewGame() Minesweeper {
+	minesweeper := newGameWithMines([]Option{Grid{3, 4}}, [2]int{0, 0}, [2]int{2, 3})
+	minesweeper.Visit(0, 3)
+	minesweeper.Flag(2, 0)
+	return minesweeper
+}
+
+func TestGameRender(t *testing.T) {
+	minesweeper := newPlayerViewGame()
+
+	var output bytes.Buffer
+	assert.NoError(t, minesweeper.(rendering.Renderer).Render(&output))
+	assert.Equal(t, "# 1 . .\n# 1 1 1\nF # # #\n", output.String())
+}
+
+func TestGameRenderLabels(t *testing.T) {
+	minesweeper := newPlayerViewGame()
+
+	var output bytes.Buffer
+	minesweeper.(rendering.Renderer).Render(&output, rendering.WithLabels())
+	assert.Equal(t, "  0 1 2 3\n0 # 1 . .\n1 # 1 1 1\n2 F # # #\n", output.String())
+
+	wide, _ := NewGame(Grid{2, 11})
+	wide.SetMineCount(1)
+	wide.Play()
+
+	output.Reset()
+	wide.(rendering.Renderer).Render(&output, rendering.WithLabels())
+	lines := strings.Split(output.String(), "\n")
+	assert.Equal(t, "   0  1  2  3  4  5  6  7  8  9 10", lines[0])
+	assert.Equal(t, "1  #  #  #  #  #  #  #  #  #  #  #", lines[2])
+}
+
+func TestGameRenderRevealAll(t *testing.T) {
+	minesweeper := newPlayerViewGame()
+
+	var output bytes.Buffer
+	minesweeper.(rendering.Renderer).Render(&output, rendering.RevealAll())
+	assert.Equal(t, "* 1 . .\n1 1 1 1\nX . 1 *\n", output.String())
+}
+
+func TestGameRenderLoss(t *testing.T) {
+	minesweeper := newPlayerViewGame()
+	minesweeper.Visit(2, 3)
+
+	var output bytes.Buffer
+	minesweeper.(rendering.Renderer).Render(&output)
+	assert.Equal(t, "* 1 . .\n# 1 1 1\nX # # @\n", output.String())
+}
+
+func TestGameRenderLayers(t *testing.T) {
+	minesweeper, _ := NewGame(Grid3D{2, 3, 2})
+	minesweeper.SetMineCount(1)
+	minesweeper.Play()
+
+	var output bytes.Buffer
+	minesweeper.(rendering.Renderer).Render(&output, rendering.WithLabels())
+	assert.Equal(t, "Layer 0\n  0 1 2\n0 # # #\n1 # # #\n\nLayer 1\n  0 1 2\n0 # # #\n1 # # #\n", output.String())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
+
+func TestGameRenderErrors(t *testing.T) {
+	minesweeper := newPlayerViewGame()
+	assert.Equal(t, io.ErrClosedPipe, minesweeper.(rendering.Renderer).Render(failingWriter{}))
+
+	minesweeper, _ = NewGame()
+	assert.IsType(t, new(UnspecifiedGridError), minesweeper.(rendering.Renderer).Render(&bytes.Buffer{}))
+}
@@ -0,0 +1,310 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import (
+	"bytes"
+	"encoding/gob"
+	"sync"
+	"time"
+
+	"github.com/rrborja/minesweeper/visited"
+)
+
+const snapshotVersion = 1
+
+// GameSnapshot is the saved state of a game, such as its board's size, the
+// location of its mines, the visited and flagged cells, the player's history
+// and the timer, that can be loaded again by the LoadGame(GameSnapshot)
+// function. The snapshot can be encoded in binary through its MarshalBinary()
+// and UnmarshalBinary([]byte) methods, or in JSON through the "encoding/json"
+// package.
+//
+// Snapshots keep loading in later versions of this package. The moves that can
+// be undone or redone and the subscriptions of the game are not saved.
+type GameSnapshot struct {
+	// Version is the version of the snapshot's format.
+	Version int `json:"version"`
+
+	Grid       *Grid      `json:"grid,omitempty"`
+	Depth      int        `json:"depth,omitempty"`
+	Difficulty Difficulty `json:"difficulty"`
+	Mines      int        `json:"mines"`
+	Seed       int64      `json:"seed"`
+
+	// Topology is the name of the built-in topology of the board. It is empty
+	// for a topology that is not built into this package, which has to be
+	// supplied again to the LoadGame(GameSnapshot, ...Option) function, unless
+	// the topology is a Neighborhood saved as its offsets.
+	Topology     string   `json:"topology,omitempty"`
+	Neighborhood [][2]int `json:"neighborhood,omitempty"`
+	WrapAround   bool     `json:"wrapAround,omitempty"`
+
+	// FirstVisitSafety is whether the mines are placed on the first visit, as
+	// set by the WithSafeFirstVisit(), WithSafeOpening() or WithNoGuess() option.
+	FirstVisitSafety uint8 `json:"firstVisitSafety"`
+	QuestionMarks    bool  `json:"questionMarks"`
+	MaxMinesPerCell  int   `json:"maxMinesPerCell,omitempty"`
+	Ranked           bool  `json:"ranked"`
+	Started          bool  `json:"started"`
+	MinesPlaced      bool  `json:"minesPlaced"`
+
+	// MineLocations, VisitedLocations, FlaggedLocations and
+	// QuestionMarkedLocations are the xy-coordinates of the respective cells.
+	// The layers of a board created with the Grid3D option follow each other
+	// along the y-coordinate, so the cell at the xyz-coordinates is located at
+	// {x, z*Grid.Height + y}. A cell holding several mines, or whose flag
+	// carries a number of mines, is located as many times as its mines.
+	MineLocations           [][2]int `json:"mineLocations"`
+	VisitedLocations        [][2]int `json:"visitedLocations"`
+	FlaggedLocations        [][2]int `json:"flaggedLocations"`
+	QuestionMarkedLocations [][2]int `json:"questionMarkedLocations,omitempty"`
+
+	// History is the player's moves from the oldest to the most recent.
+	History []HistoryRecord `json:"history"`
+
+	Moves     int           `json:"moves"`
+	StartTime time.Time     `json:"startTime"`
+	EndTime   time.Time     `json:"endTime"`
+	Elapsed   time.Duration `json:"elapsed"`
+}
+
+// HistoryRecord is the player's move saved in a GameSnapshot.
+type HistoryRecord struct {
+	X      int            `json:"x"`
+	Y      int            `json:"y"`
+	Z      int            `json:"z,omitempty"`
+	Action visited.Action `json:"action"`
+}
+
+type snapshotData GameSnapshot
+
+// MarshalBinary encodes the snapshot into a binary form.
+func (snapshot GameSnapshot) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(snapshotData(snapshot)); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the snapshot from the binary form produced by the
+// MarshalBinary() method.
+func (snapshot *GameSnapshot) UnmarshalBinary(data []byte) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode((*snapshotData)(snapshot))
+}
+
+func (game *game) Snapshot() GameSnapshot {
+	if game.Mutex != nil {
+		game.Lock()
+		defer game.Unlock()
+	}
+
+	snapshot := GameSnapshot{
+		Version:          snapshotVersion,
+		Difficulty:       game.Difficulty,
+		Mines:            game.mines,
+		Seed:             game.seed,
+		Depth:            game.depth,
+		Topology:         topologyName(game.topology),
+		WrapAround:       game.wrapAround,
+		FirstVisitSafety: uint8(game.firstVisitSafety),
+		QuestionMarks:    game.questionMarks,
+		MaxMinesPerCell:  game.maxMines,
+		Ranked:           game.ranked,
+		Started:          game.Mutex != nil,
+		MinesPlaced:      game.minesPlaced,
+		Moves:            game.moves,
+		StartTime:        game.startTime,
+		EndTime:          game.endTime,
+		Elapsed:          game.elapsed(),
+	}
+
+	if neighborhood, ok := game.topology.(Neighborhood); ok {
+		snapshot.Neighborhood = neighborhood
+	}
+
+	if game.Grid != nil {
+		snapshot.Grid = &Grid{game.Width, game.Height}
+		game.iterateBlocks(func(block *Block) bool {
+			location := [2]int{block.X(), game.row(block)}
+			for i := 0; i < block.Mines(); i++ {
+				snapshot.MineLocations = append(snapshot.MineLocations, location)
+			}
+			if block.visited {
+				snapshot.VisitedLocations = append(snapshot.VisitedLocations, location)
+			}
+			for i := 0; i < block.flags; i++ {
+				snapshot.FlaggedLocations = append(snapshot.FlaggedLocations, location)
+			}
+			if block.questioned {
+				snapshot.QuestionMarkedLocations = append(snapshot.QuestionMarkedLocations, location)
+			}
+			return true
+		})
+	}
+
+	for history := game.recordedActions.History; history != nil; history = history.History {
+		record := HistoryRecord{X: history.X(), Y: history.Y(), Z: history.Z(), Action: history.Action}
+		snapshot.History = append([]HistoryRecord{record}, snapshot.History...)
+	}
+
+	return snapshot
+}
+
+// LoadGame creates a separate minesweeper instance from the snapshot of a game
+// previously returned by the Snapshot() method. The game continues from where
+// the snapshot was taken. The elapsed time of an ongoing game resumes from the
+// time it was saved, so the StartTime reported by the State() method is moved
+// forward by the time the game was not loaded.
+//
+// The options configure what is not saved in the snapshot, such as a Topology
+// that is not built into this package, supplied by the WithTopology(Topology)
+// option.
+//
+// Like the NewGame(...Option) function, this function returns a fresh event
+// handler of the game. An UnsupportedSnapshotError will return if the snapshot's
+// version is not supported by this package, an InvalidSnapshotError will return
+// if the snapshot's Grid has no cell, its depth or maximum number of mines per
+// cell is negative or its mine locations do not add up to its number of mines,
+// and an OutOfBoundsError will return if the snapshot contains a location
+// outside its Grid. The board is validated like the Play() method does, so an
+// InvalidMineCountError, OddWrapAroundWidthError or NoGuessMultiMineError will
+// return for a board that cannot be played.
+func LoadGame(snapshot GameSnapshot, options ...Option) (Minesweeper, Event, error) {
+	if snapshot.Version < 1 || snapshot.Version > snapshotVersion {
+		return nil, nil, &UnsupportedSnapshotError{version: snapshot.Version}
+	}
+	if err := validateSnapshot(snapshot); err != nil {
+		return nil, nil, err
+	}
+
+	game := new(game)
+	game.seed = snapshot.Seed
+	game.SetDifficulty(snapshot.Difficulty)
+	game.mines = snapshot.Mines
+	game.firstVisitSafety = firstVisitSafety(snapshot.FirstVisitSafety)
+	game.questionMarks = snapshot.QuestionMarks
+	game.maxMines = snapshot.MaxMinesPerCell
+	game.ranked = snapshot.Ranked
+	game.minesPlaced = snapshot.MinesPlaced
+	game.moves = snapshot.Moves
+	game.topology = topologies[snapshot.Topology]
+	if snapshot.Neighborhood != nil {
+		game.topology = Neighborhood(snapshot.Neighborhood)
+	}
+	game.wrapAround = snapshot.WrapAround
+	game.depth = snapshot.Depth
+
+	if snapshot.Grid != nil {
+		game.SetGrid(snapshot.Grid.Width, snapshot.Grid.Height)
+	}
+	for _, option := range options {
+		option.apply(game)
+	}
+
+	if game.Grid != nil {
+		if err := game.validateBoard(); err != nil {
+			return nil, nil, err
+		}
+		if snapshot.MinesPlaced && len(snapshot.MineLocations) != game.totalBombs() {
+			return nil, nil, &InvalidSnapshotError{field: "number of mine locations", value: len(snapshot.MineLocations)}
+		}
+		if err := game.loadLocations(snapshot); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if status := game.result(); status == Won || status == Lost {
+		game.outcome = status
+	}
+
+	if snapshot.Started {
+		game.Mutex = new(sync.Mutex)
+		game.startTime = snapshot.StartTime
+		game.endTime = snapshot.EndTime
+		if game.endTime.IsZero() {
+			game.startTime = time.Now().Add(-snapshot.Elapsed)
+		}
+	}
+
+	game.Event = make(chan eventType, 1)
+
+	return game, game.Event, nil
+}
+
+// Returns an InvalidSnapshotError if the board of the snapshot cannot be created
+func validateSnapshot(snapshot GameSnapshot) error {
+	switch {
+	case snapshot.Grid != nil && snapshot.Grid.Width < 1:
+		return &InvalidSnapshotError{field: "grid width", value: snapshot.Grid.Width}
+	case snapshot.Grid != nil && snapshot.Grid.Height < 1:
+		return &InvalidSnapshotError{field: "grid height", value: snapshot.Grid.Height}
+	case snapshot.Depth < 0:
+		return &InvalidSnapshotError{field: "depth", value: snapshot.Depth}
+	case snapshot.MaxMinesPerCell < 0:
+		return &InvalidSnapshotError{field: "maximum number of mines per cell", value: snapshot.MaxMinesPerCell}
+	}
+	return nil
+}
+
+func (game *game) loadLocations(snapshot GameSnapshot) error {
+	for _, locations := range [][][2]int{snapshot.MineLocations, snapshot.VisitedLocations, snapshot.FlaggedLocations, snapshot.QuestionMarkedLocations} {
+		for _, location := range locations {
+			if err := game.validateCoordinates(location[0], location[1]%game.Height, location[1]/game.Height); err != nil {
+				return err
+			}
+		}
+	}
+	for _, record := range snapshot.History {
+		if err := game.validateCoordinates(record.X, record.Y, record.Z); err != nil {
+			return err
+		}
+	}
+
+	for _, location := range snapshot.MineLocations {
+		game.blocks[location[0]][location[1]].addMine()
+	}
+	tallyHints(game)
+
+	for _, location := range snapshot.VisitedLocations {
+		block := &game.blocks[location[0]][location[1]]
+		block.visited = true
+		if block.Node == Bomb {
+			game.exploded = true
+		} else {
+			game.revealed++
+		}
+	}
+	for _, location := range snapshot.FlaggedLocations {
+		game.blocks[location[0]][location[1]].flagged = true
+		game.blocks[location[0]][location[1]].flags++
+		game.flags++
+	}
+	for _, location := range snapshot.QuestionMarkedLocations {
+		game.blocks[location[0]][location[1]].questioned = true
+	}
+
+	for _, record := range snapshot.History {
+		game.add(visited.Record{Position: *game.block(record.X, record.Y, record.Z), Action: record.Action})
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPlayedGame() Minesweeper {
+	minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithRanking())
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+	var visits, flags int
+	for x, row := range game.blocks {
+		for y, block := range row {
+			switch {
+			case block.Node == Number && visits < 5:
+				minesweeper.Visit(x, y)
+				visits++
+			case block.Node == Bomb && flags < 3:
+				minesweeper.Flag(x, y)
+				flags++
+			}
+		}
+	}
+	return minesweeper
+}
+
+func assertSameGame(t *testing.T, expected, actual Minesweeper) {
+	assert.Equal(t, expected.(*game).blocks, actual.(*game).blocks)
+	assert.Equal(t, expected.(*game).History(), actual.(*game).History())
+
+	expectedState, actualState := expected.State(), actual.State()
+	assert.Equal(t, expectedState.Status, actualState.Status)
+	assert.Equal(t, expectedState.MinesRemaining, actualState.MinesRemaining)
+	assert.Equal(t, expectedState.UnrevealedSafeCells, actualState.UnrevealedSafeCells)
+	assert.Equal(t, expectedState.Moves, actualState.Moves)
+	assert.Equal(t, expectedState.Ranked, actualState.Ranked)
+	assert.Equal(t, expected.Seed(), actual.Seed())
+}
+
+func TestLoadGame(t *testing.T) {
+	original := newPlayedGame()
+
+	loaded, event, err := LoadGame(original.Snapshot())
+	assert.NoError(t, err)
+	assert.NotNil(t, event)
+	assert.NotEqual(t, original.(*game).Event, event)
+	assertSameGame(t, original, loaded)
+}
+
+func TestSnapshotBinaryEncoding(t *testing.T) {
+	original := newPlayedGame()
+
+	data, err := original.Snapshot().MarshalBinary()
+	assert.NoError(t, err)
+
+	var snapshot GameSnapshot
+	assert.NoError(t, snapshot.UnmarshalBinary(data))
+
+	loaded, _, err := LoadGame(snapshot)
+	assert.NoError(t, err)
+	assertSameGame(t, original, loaded)
+}
+
+func TestSnapshotJSONEncoding(t *testing.T) {
+	original := newPlayedGame()
+
+	data, err := json.Marshal(original.Snapshot())
+	assert.NoError(t, err)
+
+	var snapshot GameSnapshot
+	assert.NoError(t, json.Unmarshal(data, &snapshot))
+
+	loaded, _, err := LoadGame(snapshot)
+	assert.NoError(t, err)
+	assertSameGame(t, original, loaded)
+}
+
+func TestLoadedGameIsPlayable(t *testing.T) {
+	original := newPlayedGame()
+	loaded, event, _ := LoadGame(original.Snapshot())
+
+	game := loaded.(*game)
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node != Bomb && !block.visited {
+				if block.flagged {
+					loaded.Flag(x, y)
+				}
+				loaded.Visit(x, y)
+			}
+		}
+	}
+
+	assert.Equal(t, Won, loaded.State().Status)
+	assert.Equal(t, Win, <-event)
+}
+
+func TestLoadLostGame(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+mainLoop:
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node == Bomb {
+				minesweeper.Visit(x, y)
+				break mainLoop
+			}
+		}
+	}
+
+	loaded, _, _ := LoadGame(minesweeper.Snapshot())
+	assert.Equal(t, Lost, loaded.State().Status)
+	assert.Equal(t, minesweeper.State().Elapsed, loaded.State().Elapsed)
+}
+
+func TestLoadGameResumesElapsedTime(t *testing.T) {
+	snapshot := newPlayedGame().Snapshot()
+	snapshot.Elapsed = time.Hour
+
+	loaded, _, _ := LoadGame(snapshot)
+	assert.True(t, loaded.State().Elapsed >= time.Hour)
+	assert.True(t, loaded.State().Elapsed < time.Hour+time.Minute)
+}
+
+func TestLoadGameBeforeMinesArePlaced(t *testing.T) {
+	original, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithSafeOpening())
+	original.SetDifficulty(Medium)
+	original.Play()
+
+	loaded, _, err := LoadGame(original.Snapshot())
+	assert.NoError(t, err)
+
+	original.Visit(3, 3)
+	loaded.Visit(3, 3)
+	assertSameGame(t, original, loaded)
+}
+
+func TestLoadGameWithUnsupportedVersion(t *testing.T) {
+	snapshot := newPlayedGame().Snapshot()
+	snapshot.Version = snapshotVersion + 1
+
+	_, _, err := LoadGame(snapshot)
+	assert.Equal(t, &UnsupportedSnapshotError{version: snapshotVersion + 1}, err)
+}
+
+func TestLoadGameWithLocationOutOfBounds(t *testing.T) {
+	snapshot := newPlayedGame().Snapshot()
+	snapshot.FlaggedLocations = append(snapshot.FlaggedLocations, [2]int{sampleGridWidth, 0})
+
+	_, _, err := LoadGame(snapshot)
+	assert.IsType(t, new(OutOfBoundsError), err)
+}
+
+func TestLoadGameWithInvalidBoard(t *testing.T) {
+	snapshot := newPlayedGame().Snapshot()
+	snapshot.Grid = &Grid{-1, sampleGridHeight}
+	_, _, err := LoadGame(snapshot)
+	assert.Equal(t, &InvalidSnapshotError{field: "grid width", value: -1}, err)
+
+	snapshot = newPlayedGame().Snapshot()
+	snapshot.Grid = &Grid{sampleGridWidth, 0}
+	_, _, err = LoadGame(snapshot)
+	assert.Equal(t, &InvalidSnapshotError{field: "grid height", value: 0}, err)
+
+	snapshot = newPlayedGame().Snapshot()
+	snapshot.Depth = -2
+	_, _, err = LoadGame(snapshot)
+	assert.Equal(t, &InvalidSnapshotError{field: "depth", value: -2}, err)
+
+	snapshot = newPlayedGame().Snapshot()
+	snapshot.MaxMinesPerCell = -1
+	_, _, err = LoadGame(snapshot)
+	assert.Equal(t, &InvalidSnapshotError{field: "maximum number of mines per cell", value: -1}, err)
+}
+
+func TestLoadGameWithTooManyMines(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{3, 3}, WithSafeFirstVisit())
+	minesweeper.SetMineCount(2)
+	minesweeper.Play()
+
+	snapshot := minesweeper.Snapshot()
+	snapshot.Mines = 50
+
+	_, _, err := LoadGame(snapshot)
+	assert.Equal(t, &InvalidMineCountError{mines: 50, grid: Grid{3, 3}}, err)
+}
+
+func TestLoadGameWithMissingMineLocations(t *testing.T) {
+	snapshot := newPlayedGame().Snapshot()
+	snapshot.MineLocations = snapshot.MineLocations[1:]
+
+	_, _, err := LoadGame(snapshot)
+	assert.Equal(t, &InvalidSnapshotError{field: "number of mine locations", value: len(snapshot.MineLocations)}, err)
+}
+
+func TestLoadGameWithUnplayableOptions(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{6, 5}, WithTopology(Hexagonal), WithWrapAround())
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	snapshot := minesweeper.Snapshot()
+	snapshot.Grid = &Grid{5, 6}
+	snapshot.MineLocations = nil
+	snapshot.MinesPlaced = false
+
+	_, _, err := LoadGame(snapshot)
+	assert.IsType(t, new(OddWrapAroundWidthError), err)
+}
+
+func TestLoadGameWithQuestionMarks(t *testing.T) {
+	original, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithQuestionMarks())
+	original.SetDifficulty(Easy)
+	original.Play()
+	original.Flag(3, 6)
+	original.Flag(3, 6)
+
+	loaded, _, _ := LoadGame(original.Snapshot())
+	assert.True(t, loaded.(*game).blocks[3][6].QuestionMarked())
+
+	loaded.Flag(3, 6)
+	assert.False(t, loaded.(*game).blocks[3][6].QuestionMarked())
+}
@@ -0,0 +1,208 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+const solvableAttemptLimit = 1000
+
+// deduction simulates a player who only visits or flags a cell when the
+// revealed warning numbers prove it to be safe or a mine, respectively.
+type deduction struct {
+	*game
+	revealed map[*Block]bool
+	mines    map[*Block]bool
+}
+
+// constraint states that exactly the number of mines are hidden among the cells
+type constraint struct {
+	cells []*Block
+	mines int
+}
+
+func (game *game) placeSolvableMines(block *Block) error {
+	for attempt := 0; attempt < solvableAttemptLimit; attempt++ {
+		game.placeMines(game.safeBlocks(block)...)
+		if game.solvableFrom(block.X(), game.row(block)) {
+			return nil
+		}
+		game.clearMines()
+	}
+	return &UnsolvableBoardError{attempts: solvableAttemptLimit}
+}
+
+func (game *game) clearMines() {
+	game.iterateBlocks(func(block *Block) bool {
+		block.Node = Unknown
+		block.Value = 0
+		block.mines = 0
+		return true
+	})
+	game.minesPlaced = false
+}
+
+// Reports whether every non-mine cell can be visited without guessing when the
+// first visited cell is at the xy-coordinate.
+func (game *game) solvableFrom(x, y int) bool {
+	deduction := &deduction{
+		game:     game,
+		revealed: make(map[*Block]bool),
+		mines:    make(map[*Block]bool),
+	}
+
+	deduction.reveal(&game.blocks[x][y])
+	for deduction.deduce() {
+	}
+
+	return len(deduction.revealed) == game.totalNonBombs()
+}
+
+func (deduction *deduction) reveal(block *Block) {
+	if deduction.revealed[block] || deduction.mines[block] {
+		return
+	}
+	deduction.revealed[block] = true
+	if block.Node == Unknown {
+		deduction.traverseAdjacentCells(block.X(), deduction.row(block), deduction.reveal)
+	}
+}
+
+func (deduction *deduction) flag(block *Block) {
+	deduction.mines[block] = true
+}
+
+func (deduction *deduction) deduce() bool {
+	constraints := deduction.constraints()
+
+	if deduction.deduceSingleCells(constraints) {
+		return true
+	}
+	if deduction.deduceSubsets(constraints) {
+		return true
+	}
+	return deduction.deduceRemainingMines()
+}
+
+func (deduction *deduction) constraints() []*constraint {
+	constraints := make([]*constraint, 0)
+
+	for block := range deduction.revealed {
+		if block.Node != Number {
+			continue
+		}
+		constraint := &constraint{mines: block.Value}
+		deduction.traverseAdjacentCells(block.X(), deduction.row(block), func(cell *Block) {
+			switch {
+			case deduction.mines[cell]:
+				constraint.mines--
+			case !deduction.revealed[cell]:
+				constraint.cells = append(constraint.cells, cell)
+			}
+		})
+		if len(constraint.cells) > 0 {
+			constraints = append(constraints, constraint)
+		}
+	}
+
+	return constraints
+}
+
+// A constraint with no remaining mines has all its cells safe, and a constraint
+// with as many remaining mines as its cells has all its cells mined.
+func (deduction *deduction) deduceSingleCells(constraints []*constraint) bool {
+	var progress bool
+	for _, constraint := range constraints {
+		progress = deduction.settle(constraint.cells, constraint.mines) || progress
+	}
+	return progress
+}
+
+// When the cells of a constraint are a subset of another constraint's cells, the
+// cells outside the subset must hold the difference of both mine counts.
+func (deduction *deduction) deduceSubsets(constraints []*constraint) bool {
+	constrained := make(map[*Block][]*constraint)
+	for _, constraint := range constraints {
+		for _, cell := range constraint.cells {
+			constrained[cell] = append(constrained[cell], constraint)
+		}
+	}
+
+	var progress bool
+	for _, subset := range constraints {
+		for _, superset := range constrained[subset.cells[0]] {
+			if superset == subset || !containsAll(superset.cells, subset.cells) {
+				continue
+			}
+			difference := make([]*Block, 0, len(superset.cells))
+			for _, cell := range superset.cells {
+				if !containsAll(subset.cells, []*Block{cell}) {
+					difference = append(difference, cell)
+				}
+			}
+			progress = deduction.settle(difference, superset.mines-subset.mines) || progress
+		}
+	}
+	return progress
+}
+
+// When the remaining mines are either none or all of the hidden cells, every
+// hidden cell is settled regardless of the warning numbers.
+func (deduction *deduction) deduceRemainingMines() bool {
+	hidden := make([]*Block, 0)
+	deduction.iterateBlocks(func(block *Block) bool {
+		if !deduction.revealed[block] && !deduction.mines[block] {
+			hidden = append(hidden, block)
+		}
+		return true
+	})
+	return deduction.settle(hidden, deduction.totalBombs()-len(deduction.mines))
+}
+
+func (deduction *deduction) settle(cells []*Block, mines int) bool {
+	var progress bool
+	for _, cell := range cells {
+		if deduction.revealed[cell] || deduction.mines[cell] {
+			continue
+		}
+		switch mines {
+		case 0:
+			deduction.reveal(cell)
+			progress = true
+		case len(cells):
+			deduction.flag(cell)
+			progress = true
+		}
+	}
+	return progress
+}
+
+func containsAll(cells []*Block, subset []*Block) bool {
+	for _, wanted := range subset {
+		found := false
+		for _, cell := range cells {
+			if cell == wanted {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoGuessBoardIsSolvableFromFirstVisit(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		minesweeper, _ := NewGame(Grid{16, 16}, WithNoGuess())
+		minesweeper.SetDifficulty(Easy)
+		minesweeper.Play()
+
+		game := minesweeper.(*game)
+		x, y := coordinates.Intn(16), coordinates.Intn(16)
+		_, err := minesweeper.Visit(x, y)
+		assert.NoError(t, err)
+
+		assert.True(t, game.minesPlaced)
+		assert.Equal(t, game.totalBombs(), len(game.BombLocations()))
+		assert.True(t, game.solvableFrom(x, y))
+	}
+}
+
+func TestSolvedByDeduction(t *testing.T) {
+	game := newGameWithMines([]Option{Grid{3, 3}}, [2]int{2, 2}).(*game)
+
+	assert.True(t, game.solvableFrom(0, 0))
+}
+
+func TestUnsolvableByDeduction(t *testing.T) {
+	game := newGameWithMines([]Option{Grid{3, 2}}, [2]int{2, 0}).(*game)
+
+	// The mine is a coin flip between the two cells of the last column
+	assert.False(t, game.solvableFrom(0, 0))
+}
+
+func TestNoGuessReportsUnsolvableBoard(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{3, 2}, WithNoGuess())
+	minesweeper.SetDifficulty(Medium)
+	minesweeper.Play()
+
+	// The only mine is always a coin flip between the two cells outside the opening
+	_, err := minesweeper.Visit(0, 0)
+	assert.IsType(t, new(UnsolvableBoardError), err)
+	assert.False(t, minesweeper.(*game).minesPlaced)
+}
@@ -0,0 +1,385 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package solver
+
+import "fmt"
+
+// maxPartialArrangements is the most partial arrangements of a group of the
+// frontier kept while counting the arrangements of its mines. Larger groups are
+// left undecided.
+const maxPartialArrangements = 1 << 12
+
+// component is a group of hidden cells along the frontier of the revealed cells
+// that share warning numbers, so the arrangements of its mines are independent
+// of the other groups except for the total number of mines
+type component struct {
+	cells       [][2]int
+	constraints []*constraint
+
+	// arrangements is the number of arrangements of the group for every number
+	// of mines, and mines is the number of those arrangements with a mine at
+	// each cell
+	arrangements map[int]float64
+	mines        map[int][]float64
+}
+
+// Groups the cells of the constraints into components sharing warning numbers
+func components(constraints []*constraint) []*component {
+	parent := make([]int, len(constraints))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	owner := make(map[[2]int]int)
+	for i, constraint := range constraints {
+		for _, cell := range constraint.cells {
+			if j, ok := owner[cell]; ok {
+				parent[find(i)] = find(j)
+			} else {
+				owner[cell] = i
+			}
+		}
+	}
+
+	groups := make(map[int]*component)
+	ordered := make([]*component, 0)
+	for i, constraint := range constraints {
+		root := find(i)
+		group, ok := groups[root]
+		if !ok {
+			group = &component{}
+			groups[root] = group
+			ordered = append(ordered, group)
+		}
+		group.constraints = append(group.constraints, constraint)
+		for _, cell := range constraint.cells {
+			if owner[cell] == i {
+				group.cells = append(group.cells, cell)
+			}
+		}
+	}
+	return ordered
+}
+
+// partial is the arrangements of the first cells of a component sharing the
+// number of mines placed around each warning number
+type partial struct {
+	placed []uint8
+
+	// ways is the number of arrangements for every number of mines among the
+	// first cells, and completions is the number of ways to arrange the mines of
+	// the remaining cells for every number of those mines
+	ways        []float64
+	completions []float64
+
+	// next is the partial arrangement reached by leaving the next cell free of
+	// mines or by placing a mine on it
+	next [2]*partial
+}
+
+// Counts every arrangement of mines of the component satisfying its constraints.
+// The cells are visited in breadth-first order so only a few warning numbers are
+// partially arranged at a time, and the arrangements of the first cells are
+// merged whenever they place the same number of mines around every warning
+// number. It reports false when the component is too large to be counted.
+func (component *component) enumerate() bool {
+	component.order()
+
+	n := len(component.cells)
+	index := make(map[[2]int]int, n)
+	for i, cell := range component.cells {
+		index[cell] = i
+	}
+	cellConstraints := make([][]int, n)
+	for c, constraint := range component.constraints {
+		for _, cell := range constraint.cells {
+			cellConstraints[index[cell]] = append(cellConstraints[index[cell]], c)
+		}
+	}
+	// open is the number of cells of each constraint after the cell
+	open := make([][]int, n)
+	for i := range open {
+		open[i] = make([]int, len(cellConstraints[i]))
+		for j, c := range cellConstraints[i] {
+			for _, cell := range component.constraints[c].cells {
+				if index[cell] > i {
+					open[i][j]++
+				}
+			}
+		}
+	}
+
+	layers := make([][]*partial, n+1)
+	layers[0] = []*partial{{placed: make([]uint8, len(component.constraints)), ways: []float64{1}}}
+	for i := 0; i < n; i++ {
+		reached := make(map[string]*partial)
+		for _, current := range layers[i] {
+			for mine := 0; mine <= 1; mine++ {
+				placed := append([]uint8(nil), current.placed...)
+				consistent := true
+				for j, c := range cellConstraints[i] {
+					placed[c] += uint8(mine)
+					mines := component.constraints[c].mines
+					if int(placed[c]) > mines || int(placed[c])+open[i][j] < mines {
+						consistent = false
+					}
+				}
+				if !consistent {
+					continue
+				}
+				next, ok := reached[string(placed)]
+				if !ok {
+					next = &partial{placed: placed}
+					reached[string(placed)] = next
+					layers[i+1] = append(layers[i+1], next)
+				}
+				for len(next.ways) < len(current.ways)+mine {
+					next.ways = append(next.ways, 0)
+				}
+				for mines, ways := range current.ways {
+					next.ways[mines+mine] += ways
+				}
+				current.next[mine] = next
+			}
+		}
+		if len(layers[i+1]) > maxPartialArrangements {
+			return false
+		}
+	}
+
+	for _, last := range layers[n] {
+		last.completions = []float64{1}
+	}
+	for i := n - 1; i >= 0; i-- {
+		for _, current := range layers[i] {
+			for mine, next := range current.next {
+				if next == nil {
+					continue
+				}
+				for len(current.completions) < len(next.completions)+mine {
+					current.completions = append(current.completions, 0)
+				}
+				for mines, completions := range next.completions {
+					current.completions[mines+mine] += completions
+				}
+			}
+		}
+	}
+
+	component.arrangements = make(map[int]float64)
+	component.mines = make(map[int][]float64)
+	for mines, arrangements := range layers[0][0].completions {
+		if arrangements > 0 {
+			component.arrangements[mines] = arrangements
+			component.mines[mines] = make([]float64, n)
+		}
+	}
+	for i := 0; i < n; i++ {
+		for _, current := range layers[i] {
+			next := current.next[1]
+			if next == nil {
+				continue
+			}
+			for before, ways := range current.ways {
+				for after, completions := range next.completions {
+					if ways > 0 && completions > 0 {
+						component.mines[before+1+after][i] += ways * completions
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Orders the cells of the component breadth-first through their warning numbers
+func (component *component) order() {
+	constrained := make(map[[2]int][]*constraint)
+	for _, constraint := range component.constraints {
+		for _, cell := range constraint.cells {
+			constrained[cell] = append(constrained[cell], constraint)
+		}
+	}
+
+	ordered := make([][2]int, 0, len(component.cells))
+	queued := map[[2]int]bool{component.cells[0]: true}
+	queue := [][2]int{component.cells[0]}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		ordered = append(ordered, cell)
+		for _, constraint := range constrained[cell] {
+			for _, neighbor := range constraint.cells {
+				if !queued[neighbor] {
+					queued[neighbor] = true
+					queue = append(queue, neighbor)
+				}
+			}
+		}
+	}
+	component.cells = ordered
+}
+
+// Returns the least and the most mines among the arrangements of the component.
+// A component that is not enumerated can hold from none to all of its cells.
+func (component *component) bounds() (least, most int) {
+	if component.arrangements == nil {
+		return 0, len(component.cells)
+	}
+	least = len(component.cells)
+	for mines := range component.arrangements {
+		if mines < least {
+			least = mines
+		}
+		if mines > most {
+			most = mines
+		}
+	}
+	return
+}
+
+// frontier is the hidden cells of the board split into the enumerated
+// components and the interior cells away from any warning number
+type frontier struct {
+	components []*component
+	interior   [][2]int
+	mines      int
+}
+
+func (solver *solver) frontier() *frontier {
+	constraints := solver.constraints()
+	frontier := &frontier{components: components(constraints), mines: solver.remainingMines()}
+
+	constrained := make(map[[2]int]bool)
+	for _, component := range frontier.components {
+		component.enumerate()
+		for _, cell := range component.cells {
+			constrained[cell] = true
+		}
+	}
+	for _, cell := range solver.unknown() {
+		if !constrained[cell] {
+			frontier.interior = append(frontier.interior, cell)
+		}
+	}
+	return frontier
+}
+
+// Reports whether the component can hold the number of mines given the bounds
+// of the other components and the interior cells.
+func (frontier *frontier) feasible(component *component, mines int) bool {
+	least, most := mines, mines+len(frontier.interior)
+	for _, other := range frontier.components {
+		if other != component {
+			otherLeast, otherMost := other.bounds()
+			least += otherLeast
+			most += otherMost
+		}
+	}
+	return least <= frontier.mines && most >= frontier.mines
+}
+
+// A cell that is a mine in every arrangement of its component, or in none of
+// them, is settled. The total number of mines rules out the arrangements that
+// leave too many or too few mines for the rest of the board, and settles the
+// interior cells when the components hold all or none of the remaining mines.
+func (solver *solver) deduceByEnumeration() bool {
+	frontier := solver.frontier()
+
+	var progress bool
+	for _, component := range frontier.components {
+		if component.arrangements == nil {
+			continue
+		}
+
+		var total float64
+		mines := make([]float64, len(component.cells))
+		var constrainedByCount bool
+		for count, arrangements := range component.arrangements {
+			if !frontier.feasible(component, count) {
+				constrainedByCount = true
+				continue
+			}
+			total += arrangements
+			for i, arrangementsWithMine := range component.mines[count] {
+				mines[i] += arrangementsWithMine
+			}
+		}
+		if total == 0 {
+			continue
+		}
+
+		numbers := make([][2]int, len(component.constraints))
+		for i, constraint := range component.constraints {
+			numbers[i] = constraint.number
+		}
+		reason := "Every arrangement of mines satisfying " + solver.describeNumbers(numbers)
+		rule := Enumeration
+		if constrainedByCount {
+			reason += fmt.Sprintf(" with %v", count(frontier.mines, "remaining mine"))
+			rule = MineCount
+		}
+		reason += " agrees on that cell"
+
+		for i, cell := range component.cells {
+			switch mines[i] {
+			case 0:
+				progress = solver.deduce(cell, false, rule, numbers, reason) || progress
+			case total:
+				progress = solver.deduce(cell, true, rule, numbers, reason) || progress
+			}
+		}
+	}
+	if progress || len(frontier.interior) == 0 {
+		return progress
+	}
+
+	least, most := frontier.mines, frontier.mines
+	for _, component := range frontier.components {
+		componentLeast, componentMost := component.bounds()
+		least -= componentMost
+		most -= componentLeast
+	}
+	switch {
+	case most <= 0:
+		reason := "No mines remain"
+		if frontier.mines > 0 {
+			reason = fmt.Sprintf("The hidden cells next to the warning numbers must hold the %v",
+				count(frontier.mines, "remaining mine"))
+		}
+		for _, cell := range frontier.interior {
+			progress = solver.deduce(cell, false, MineCount, nil, reason) || progress
+		}
+	case least >= len(frontier.interior):
+		reason := fmt.Sprintf("The %v away from the warning numbers must hold %v of the %v",
+			count(len(frontier.interior), "hidden cell"), least, count(frontier.mines, "remaining mine"))
+		for _, cell := range frontier.interior {
+			progress = solver.deduce(cell, true, MineCount, nil, reason) || progress
+		}
+	}
+	return progress
+}
@@ -0,0 +1,40 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package solver
+
+import "fmt"
+
+// NoHintError is the error type used to handle errors when a hint is requested
+// but the game is over or no hidden cell is left to be visited.
+type NoHintError struct{}
+
+func (NoHint NoHintError) Error() string {
+	return "No cell is left to visit."
+}
+
+// UnsupportedBoardError is the error type used to handle errors when the board of a
+// game cannot be solved, such as a board whose edges wrap around.
+type UnsupportedBoardError struct {
+	feature string
+}
+
+func (UnsupportedBoard UnsupportedBoardError) Error() string {
+	return fmt.Sprintf("Boards %v are not supported by the solver.", UnsupportedBoard.feature)
+}
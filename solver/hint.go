@@ -0,0 +1,153 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package solver
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rrborja/minesweeper"
+)
+
+// Hint is the hidden cell recommended to be visited next.
+type Hint struct {
+	// X and Y are the coordinates of the cell.
+	X, Y int
+
+	// Safe reports whether the cell is proven to be safe. Otherwise, the cell
+	// is the guess least likely to be a mine.
+	Safe bool
+
+	// Probability is the chance of the cell being a mine, which is zero for a
+	// safe cell.
+	Probability float64
+
+	// Numbers are the xy-coordinates of the warning numbers the hint is based
+	// on.
+	Numbers [][2]int
+
+	// Reason explains the hint in words.
+	Reason string
+}
+
+// Assistant is a game that recommends its player the next cell to visit.
+type Assistant interface {
+	minesweeper.Minesweeper
+
+	Hint() (Hint, error)
+}
+
+type assistant struct {
+	minesweeper.Minesweeper
+}
+
+// Assist wraps the game so that the Hint() method can be called alongside the
+// methods of the game.
+func Assist(game minesweeper.Minesweeper) Assistant {
+	return assistant{game}
+}
+
+// Hint recommends the next cell to visit from what the player is allowed to see.
+// The NoHintError is returned once the game is over and the
+// UnsupportedBoardError is returned for a board the solver cannot read.
+func (assistant assistant) Hint() (Hint, error) {
+	switch assistant.State().Status {
+	case minesweeper.Won, minesweeper.Lost:
+		return Hint{}, &NoHintError{}
+	}
+	board, err := NewBoard(assistant)
+	if err != nil {
+		return Hint{}, err
+	}
+	return board.Hint()
+}
+
+// Hint recommends the next cell to visit. A cell proven to be safe is preferred,
+// the simplest deduction first. When no cell is proven to be safe, the hidden
+// cell least likely to be a mine is recommended and, among equally likely cells,
+// the one with the fewest neighboring cells as it is the most likely to reveal
+// an opening. A flagged cell is only recommended when its flag is proven wrong.
+// The NoHintError is returned when no hidden cell is left to be visited.
+func (board *Board) Hint() (Hint, error) {
+	solver := newSolver(board)
+	solver.deduceAll()
+
+	var wrongFlag *Deduction
+	for i, deduction := range solver.deductions {
+		if deduction.Mine {
+			continue
+		}
+		if solver.cell([2]int{deduction.X, deduction.Y}).State == minesweeper.Flagged {
+			if wrongFlag == nil {
+				wrongFlag = &solver.deductions[i]
+			}
+			continue
+		}
+		return Hint{X: deduction.X, Y: deduction.Y, Safe: true, Numbers: deduction.Numbers, Reason: deduction.Reason}, nil
+	}
+	if wrongFlag != nil {
+		return Hint{
+			X: wrongFlag.X, Y: wrongFlag.Y, Safe: true, Numbers: wrongFlag.Numbers,
+			Reason: wrongFlag.Reason + " Remove its flag to visit it.",
+		}, nil
+	}
+
+	probabilities := solver.probabilities()
+	var guess [2]int
+	least, fewest := math.Inf(1), 0
+	solver.iterate(func(location [2]int) {
+		switch solver.cell(location).State {
+		case minesweeper.Hidden, minesweeper.QuestionMarked:
+		default:
+			return
+		}
+		probability, neighbors := probabilities[location[0]][location[1]], len(solver.neighbors(location))
+		if probability < least-1e-9 || probability < least+1e-9 && neighbors < fewest {
+			guess, least, fewest = location, probability, neighbors
+		}
+	})
+	if math.IsInf(least, 1) {
+		return Hint{}, &NoHintError{}
+	}
+
+	numbers := make([][2]int, 0)
+	for _, neighbor := range solver.neighbors(guess) {
+		if solver.cell(neighbor).State == minesweeper.RevealedNumber {
+			numbers = append(numbers, neighbor)
+		}
+	}
+	var reason string
+	switch {
+	case len(solver.constraints()) == 0:
+		reason = fmt.Sprintf("No warning number is revealed yet, so every hidden cell has a %v chance of being a mine. %v has the fewest neighboring cells, so it is the most likely to reveal an opening.",
+			percent(least), capitalize(describe(guess)))
+	case len(numbers) == 0:
+		reason = fmt.Sprintf("No hidden cell is certainly safe. Away from the warning numbers, %v has the lowest chance of being a mine at %v.",
+			describe(guess), percent(least))
+	default:
+		reason = fmt.Sprintf("No hidden cell is certainly safe. Next to %v, %v has the lowest chance of being a mine at %v.",
+			solver.describeNumbers(numbers), describe(guess), percent(least))
+	}
+	return Hint{X: guess[0], Y: guess[1], Probability: least, Numbers: numbers, Reason: reason}, nil
+}
+
+func percent(probability float64) string {
+	return fmt.Sprintf("%v%%", math.Round(probability*1000)/10)
+}
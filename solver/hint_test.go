@@ -0,0 +1,159 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package solver
+
+import (
+	"testing"
+
+	"github.com/rrborja/minesweeper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHintSafeCell(t *testing.T) {
+	hint, err := newBoard(2,
+		"...",
+		"121",
+	).Hint()
+
+	assert.NoError(t, err)
+	assert.Equal(t, Hint{
+		X: 0, Y: 1, Safe: true, Numbers: [][2]int{{1, 2}},
+		Reason: "The 1 at (1, 2) already touches 1 mine, so (0, 1) is safe.",
+	}, hint)
+}
+
+func TestHintGuessNextToNumbers(t *testing.T) {
+	hint, err := newBoard(2,
+		"1.1",
+		"...",
+		"...",
+	).Hint()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, hint.X)
+	assert.Equal(t, 0, hint.Y)
+	assert.False(t, hint.Safe)
+	assert.InDelta(t, 1.0/7, hint.Probability, 1e-9)
+	assert.Equal(t, [][2]int{{0, 0}}, hint.Numbers)
+	assert.Equal(t, "No hidden cell is certainly safe. Next to the 1 at (0, 0), (1, 0) has the lowest chance of being a mine at 14.3%.", hint.Reason)
+}
+
+func TestHintGuessAwayFromNumbers(t *testing.T) {
+	hint, err := newBoard(2,
+		"1.",
+		"..",
+		"..",
+		"..",
+	).Hint()
+
+	assert.NoError(t, err)
+	assert.Equal(t, Hint{
+		X: 3, Y: 0, Probability: 0.25, Numbers: [][2]int{},
+		Reason: "No hidden cell is certainly safe. Away from the warning numbers, (3, 0) has the lowest chance of being a mine at 25%.",
+	}, hint)
+}
+
+func TestHintFirstVisit(t *testing.T) {
+	hint, err := newBoard(1,
+		"...",
+		"...",
+		"...",
+	).Hint()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, hint.X)
+	assert.Equal(t, 0, hint.Y)
+	assert.Equal(t, "No warning number is revealed yet, so every hidden cell has a 11.1% chance of being a mine. (0, 0) has the fewest neighboring cells, so it is the most likely to reveal an opening.", hint.Reason)
+}
+
+func TestHintWrongFlag(t *testing.T) {
+	hint, err := newBoard(1,
+		"0F",
+		"11",
+		"..",
+	).Hint()
+
+	assert.NoError(t, err)
+	assert.Equal(t, Hint{
+		X: 0, Y: 1, Safe: true, Numbers: [][2]int{{0, 0}},
+		Reason: "The blank at (0, 0) touches no mines, so (0, 1) is safe. Remove its flag to visit it.",
+	}, hint)
+}
+
+func TestNoHint(t *testing.T) {
+	_, err := newBoard(1,
+		"11",
+		"1*",
+	).Hint()
+
+	assert.Error(t, err)
+	assert.IsType(t, &NoHintError{}, err)
+	assert.Equal(t, "No cell is left to visit.", err.Error())
+}
+
+// Plays games by the hints only, verifying that a safe hint is never a mine.
+func TestAssist(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		game, _ := minesweeper.NewGame(minesweeper.Beginner, minesweeper.WithSeed(seed), minesweeper.WithSafeFirstVisit())
+		assistant := Assist(game)
+		assistant.Play()
+
+		for assistant.State().Status != minesweeper.Won && assistant.State().Status != minesweeper.Lost {
+			hint, err := assistant.Hint()
+			assert.NoError(t, err)
+			_, err = assistant.Visit(hint.X, hint.Y)
+			if hint.Safe {
+				assert.NoError(t, err, hint.Reason)
+			}
+		}
+
+		_, err := assistant.Hint()
+		assert.IsType(t, &NoHintError{}, err)
+	}
+}
+
+// Plays hexagonal games by the hints only, verifying that the hints follow the
+// topology of the game.
+func TestAssistHexagonalBoard(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		game, _ := minesweeper.NewGame(minesweeper.Beginner, minesweeper.WithTopology(minesweeper.Hexagonal),
+			minesweeper.WithSeed(seed), minesweeper.WithSafeFirstVisit())
+		assistant := Assist(game)
+		assistant.Play()
+
+		for assistant.State().Status == minesweeper.Ongoing || assistant.State().Status == minesweeper.NotStarted {
+			hint, err := assistant.Hint()
+			assert.NoError(t, err)
+			_, err = assistant.Visit(hint.X, hint.Y)
+			if hint.Safe {
+				assert.NoError(t, err, hint.Reason)
+			}
+		}
+	}
+}
+
+func TestAssistUnsupportedBoard(t *testing.T) {
+	game, _ := minesweeper.NewGame(minesweeper.Beginner, minesweeper.WithWrapAround())
+	assistant := Assist(game)
+	assistant.Play()
+
+	_, err := assistant.Hint()
+	assert.IsType(t, &UnsupportedBoardError{}, err)
+}
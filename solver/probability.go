@@ -0,0 +1,197 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package solver
+
+import (
+	"math"
+
+	"github.com/rrborja/minesweeper"
+)
+
+// Probabilities returns the chance of every cell of the game's board being a
+// mine. It is the same as calling NewBoard(game) and the Probabilities() method
+// of the board.
+func Probabilities(game minesweeper.Minesweeper) ([][]float64, error) {
+	board, err := NewBoard(game)
+	if err != nil {
+		return nil, err
+	}
+	return board.Probabilities(), nil
+}
+
+// Probabilities returns the chance of every cell of the board being a mine,
+// indexed by the xy-coordinates of the cells like the View of the board. The
+// chance is exact: every arrangement of mines satisfying the warning numbers and
+// the total number of mines is equally likely, so a hidden cell away from the
+// warning numbers is weighed by the number of ways to place the mines left over
+// by each arrangement of the frontier.
+//
+// A revealed cell has no chance of being a mine unless the revealed cell is a
+// mine. A cell deduced by the Deduce() method is certainly safe or a mine. The
+// cells of a group of the frontier that is too large to be counted are given
+// the same chance as the cells away from the warning numbers.
+func (board *Board) Probabilities() [][]float64 {
+	solver := newSolver(board)
+	solver.deduceAll()
+	return solver.probabilities()
+}
+
+// Returns the probabilities of the cells once every deduction is made
+func (solver *solver) probabilities() [][]float64 {
+	chances := solver.frontier().probabilities()
+
+	probabilities := make([][]float64, solver.width)
+	for x := range probabilities {
+		probabilities[x] = make([]float64, solver.height)
+	}
+	solver.iterate(func(location [2]int) {
+		mine, known := solver.known[location]
+		switch {
+		case solver.revealedMine(location) || known && mine:
+			probabilities[location[0]][location[1]] = 1
+		case !known && solver.hidden(location):
+			probabilities[location[0]][location[1]] = chances[location]
+		}
+	})
+	return probabilities
+}
+
+// Weighs the arrangements of every component by the number of ways to place the
+// remaining mines among the interior cells, which is the binomial coefficient of
+// the interior cells and the mines left over by the components.
+func (frontier *frontier) probabilities() map[[2]int]float64 {
+	interior := append([][2]int(nil), frontier.interior...)
+	counted := make([]*component, 0, len(frontier.components))
+	for _, component := range frontier.components {
+		if component.arrangements == nil {
+			interior = append(interior, component.cells...)
+		} else {
+			counted = append(counted, component)
+		}
+	}
+
+	// The arrangements of each component are scaled down to its most common
+	// number of mines, and the ways to fill the interior cells are scaled down
+	// to the most likely number of mines, so that neither overflows
+	distributions := make([][]float64, len(counted))
+	scales := make([]float64, len(counted))
+	most := 0
+	for i, component := range counted {
+		_, componentMost := component.bounds()
+		most += componentMost
+		distributions[i] = make([]float64, componentMost+1)
+		for mines, arrangements := range component.arrangements {
+			scales[i] = math.Max(scales[i], arrangements)
+			distributions[i][mines] = arrangements
+		}
+		for mines := range distributions[i] {
+			distributions[i][mines] /= scales[i]
+		}
+	}
+	fills := make([]float64, most+1)
+	reference := math.Inf(-1)
+	for mines := range fills {
+		if left := frontier.mines - mines; left >= 0 && left <= len(interior) {
+			reference = math.Max(reference, logBinomial(len(interior), left))
+		}
+	}
+	for mines := range fills {
+		if left := frontier.mines - mines; left >= 0 && left <= len(interior) {
+			fills[mines] = math.Exp(logBinomial(len(interior), left) - reference)
+		}
+	}
+
+	// prefixes[i] and suffixes[i] are the distributions of the mines of the
+	// components before and after the ith component
+	prefixes := make([][]float64, len(counted)+1)
+	suffixes := make([][]float64, len(counted)+1)
+	prefixes[0], suffixes[len(counted)] = []float64{1}, []float64{1}
+	for i := range counted {
+		prefixes[i+1] = convolve(prefixes[i], distributions[i])
+		suffixes[len(counted)-1-i] = convolve(distributions[len(counted)-1-i], suffixes[len(counted)-i])
+	}
+
+	var total, interiorMines float64
+	for mines, weight := range prefixes[len(counted)] {
+		total += weight * fills[mines]
+		interiorMines += weight * fills[mines] * float64(frontier.mines-mines)
+	}
+
+	probabilities := make(map[[2]int]float64)
+	if total == 0 {
+		// No arrangement fits the board, so the remaining mines are spread evenly
+		cells := len(interior)
+		for _, component := range counted {
+			cells += len(component.cells)
+		}
+		for _, component := range frontier.components {
+			for _, cell := range component.cells {
+				probabilities[cell] = density(frontier.mines, cells)
+			}
+		}
+		for _, cell := range interior {
+			probabilities[cell] = density(frontier.mines, cells)
+		}
+		return probabilities
+	}
+
+	for _, cell := range interior {
+		probabilities[cell] = interiorMines / float64(len(interior)) / total
+	}
+	for i, component := range counted {
+		others := convolve(prefixes[i], suffixes[i+1])
+		for mines, arrangementsWithMine := range component.mines {
+			var weight float64
+			for otherMines, otherWeight := range others {
+				if mines+otherMines < len(fills) {
+					weight += otherWeight * fills[mines+otherMines]
+				}
+			}
+			for j, cell := range component.cells {
+				probabilities[cell] += arrangementsWithMine[j] / scales[i] * weight / total
+			}
+		}
+	}
+	return probabilities
+}
+
+func convolve(a, b []float64) []float64 {
+	convolution := make([]float64, len(a)+len(b)-1)
+	for i, x := range a {
+		for j, y := range b {
+			convolution[i+j] += x * y
+		}
+	}
+	return convolution
+}
+
+func logBinomial(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
+
+func density(mines, cells int) float64 {
+	if cells == 0 {
+		return 0
+	}
+	return math.Min(1, math.Max(0, float64(mines)/float64(cells)))
+}
@@ -0,0 +1,180 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package solver
+
+import (
+	"testing"
+
+	"github.com/rrborja/minesweeper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbabilitiesOfFrontier(t *testing.T) {
+	probabilities := newBoard(1,
+		"1.",
+		"..",
+	).Probabilities()
+
+	assert.Equal(t, 0.0, probabilities[0][0])
+	assert.InDelta(t, 1.0/3, probabilities[0][1], 1e-9)
+	assert.InDelta(t, 1.0/3, probabilities[1][0], 1e-9)
+	assert.InDelta(t, 1.0/3, probabilities[1][1], 1e-9)
+}
+
+func TestProbabilitiesOfInterior(t *testing.T) {
+	probabilities := newBoard(2,
+		"1.",
+		"..",
+		"..",
+	).Probabilities()
+
+	assert.InDelta(t, 1.0/3, probabilities[0][1], 1e-9)
+	assert.InDelta(t, 1.0/2, probabilities[2][0], 1e-9, "The other mine is at either interior cell")
+	assert.InDelta(t, 1.0/2, probabilities[2][1], 1e-9)
+}
+
+// A mine shared by both 1s can be placed in 2 ways, leaving 1 mine for the 3
+// interior cells, while a mine for each 1 leaves no mines for them. The shared
+// cells are three times as likely to be a mine as the other frontier cells.
+func TestProbabilitiesWeighedByInterior(t *testing.T) {
+	probabilities := newBoard(2,
+		"1.1",
+		"...",
+		"...",
+	).Probabilities()
+
+	assert.InDelta(t, 3.0/7, probabilities[0][1], 1e-9)
+	assert.InDelta(t, 3.0/7, probabilities[1][1], 1e-9)
+	assert.InDelta(t, 1.0/7, probabilities[1][0], 1e-9)
+	assert.InDelta(t, 1.0/7, probabilities[1][2], 1e-9)
+	for y := 0; y < 3; y++ {
+		assert.InDelta(t, 2.0/7, probabilities[2][y], 1e-9)
+	}
+}
+
+func TestProbabilitiesOfDeductions(t *testing.T) {
+	probabilities := newBoard(1,
+		"11",
+		"1.",
+	).Probabilities()
+
+	assert.Equal(t, [][]float64{{0, 0}, {0, 1}}, probabilities)
+}
+
+// Compares the probabilities with the share of every arrangement of the mines
+// among the hidden cells that satisfies the warning numbers.
+func TestProbabilitiesMatchBruteForce(t *testing.T) {
+	for seed := int64(0); seed < 30; seed++ {
+		game, _ := minesweeper.NewGame(minesweeper.CustomDifficulty{Width: 5, Height: 5, Mines: 5}, minesweeper.WithSeed(seed), minesweeper.WithSafeFirstVisit())
+		game.Play()
+		game.Visit(2, 2)
+		if game.State().Status != minesweeper.Ongoing {
+			continue
+		}
+
+		board, _ := NewBoard(game)
+		expected := bruteForce(board)
+		actual := board.Probabilities()
+		for x := range expected {
+			for y := range expected[x] {
+				assert.InDelta(t, expected[x][y], actual[x][y], 1e-9)
+			}
+		}
+	}
+}
+
+func bruteForce(board *Board) [][]float64 {
+	solver := newSolver(board)
+	hidden := solver.unknown()
+
+	mines := make(map[[2]int]bool)
+	counts := make([]float64, len(hidden))
+	var total float64
+	var place func(i, left int)
+	place = func(i, left int) {
+		if left == 0 {
+			for _, constraint := range solver.constraints() {
+				placed := 0
+				for _, cell := range constraint.cells {
+					if mines[cell] {
+						placed++
+					}
+				}
+				if placed != constraint.mines {
+					return
+				}
+			}
+			total++
+			for j, cell := range hidden {
+				if mines[cell] {
+					counts[j]++
+				}
+			}
+			return
+		}
+		if len(hidden)-i < left {
+			return
+		}
+		mines[hidden[i]] = true
+		place(i+1, left-1)
+		mines[hidden[i]] = false
+		place(i+1, left)
+	}
+	place(0, board.Mines)
+
+	probabilities := make([][]float64, solver.width)
+	for x := range probabilities {
+		probabilities[x] = make([]float64, solver.height)
+	}
+	for j, cell := range hidden {
+		probabilities[cell[0]][cell[1]] = counts[j] / total
+	}
+	return probabilities
+}
+
+// Plays expert games by visiting the cell least likely to be a mine, verifying
+// that the probabilities of all cells add up to the number of mines.
+func TestProbabilitiesOfExpertBoard(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		game, _ := minesweeper.NewGame(minesweeper.Expert, minesweeper.WithSeed(seed), minesweeper.WithSafeOpening())
+		game.Play()
+		game.Visit(15, 8)
+
+		for game.State().Status == minesweeper.Ongoing {
+			probabilities, err := Probabilities(game)
+			assert.NoError(t, err)
+
+			var sum float64
+			safest, least := [2]int{}, 2.0
+			view := game.View()
+			for x := range probabilities {
+				for y, probability := range probabilities[x] {
+					assert.True(t, probability >= 0 && probability <= 1+1e-9)
+					sum += probability
+					if view[x][y].State == minesweeper.Hidden && probability < least {
+						safest, least = [2]int{x, y}, probability
+					}
+				}
+			}
+			assert.InDelta(t, 99, sum, 1e-6)
+			game.Visit(safest[0], safest[1])
+		}
+	}
+}
@@ -0,0 +1,389 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+// Package solver deduces the cells of a minesweeper game that are certainly safe
+// or certainly mines from what the player is allowed to see. The deductions are
+// made from the game's View() and State() methods only, so the solver never
+// relies on the location of an unrevealed mine.
+package solver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rrborja/minesweeper"
+)
+
+// Rule is the reasoning used to make a deduction.
+// Values of this type are solver.SingleCell, solver.Subset, solver.Enumeration
+// and solver.MineCount
+type Rule uint8
+
+const (
+	// SingleCell is the rule of a warning number whose remaining mines are
+	// either none or all of its hidden neighboring cells.
+	SingleCell Rule = iota
+
+	// Subset is the rule of a warning number whose hidden neighboring cells are
+	// all neighbors of another warning number, so the cells of the other number
+	// outside the subset hold the difference of both numbers.
+	Subset
+
+	// Enumeration is the rule of a cell that is a mine, or free of mines, in
+	// every arrangement of mines satisfying the warning numbers around it.
+	Enumeration
+
+	// MineCount is the rule of the total number of mines of the game, which
+	// settles the hidden cells away from any warning number.
+	MineCount
+)
+
+// Deduction is a hidden cell proven to be safe or proven to be a mine.
+type Deduction struct {
+	// X and Y are the coordinates of the cell.
+	X, Y int
+
+	// Mine reports whether the cell is a mine. Otherwise, the cell is safe.
+	Mine bool
+
+	// Rule is the reasoning used to make the deduction.
+	Rule Rule
+
+	// Numbers are the xy-coordinates of the warning numbers the deduction is
+	// made from.
+	Numbers [][2]int
+
+	// Reason explains the deduction in words.
+	Reason string
+}
+
+// Board is the player-visible state of a game to solve. Boards of any Topology
+// can be solved as long as the board is flat, its edges are not connected and
+// its cells hold a single mine, as checked by the ReadBoard function.
+type Board struct {
+	// View is the board as observed by the player.
+	View minesweeper.BoardView
+
+	// Mines is the total number of mines of the game.
+	Mines int
+
+	// Topology defines the neighboring cells of every cell. The
+	// minesweeper.Square topology is used when not set.
+	Topology minesweeper.Topology
+}
+
+// NewBoard reads the player-visible state of the game, including the Topology of
+// its board. It is the same as calling ReadBoard(game.View(), game.State()).
+func NewBoard(game minesweeper.Minesweeper) (*Board, error) {
+	return ReadBoard(game.View(), game.State())
+}
+
+// ReadBoard creates the board of a game from its View and its State. An
+// UnsupportedBoardError is returned when the edges of the board wrap around,
+// the board has several layers or its cells can hold several mines.
+func ReadBoard(view minesweeper.BoardView, state minesweeper.GameState) (*Board, error) {
+	switch {
+	case state.WrapAround:
+		return nil, &UnsupportedBoardError{feature: "whose edges wrap around"}
+	case state.Depth > 1:
+		return nil, &UnsupportedBoardError{feature: "of several layers"}
+	case state.MaxMinesPerCell > 1:
+		return nil, &UnsupportedBoardError{feature: "whose cells hold several mines"}
+	}
+	return &Board{
+		View:     view,
+		Mines:    state.MinesRemaining + state.FlagsPlaced,
+		Topology: state.Topology,
+	}, nil
+}
+
+// Solve returns every deduction that can be made on the board of the game. It is
+// the same as calling NewBoard(game) and the Deduce() method of the board.
+func Solve(game minesweeper.Minesweeper) ([]Deduction, error) {
+	board, err := NewBoard(game)
+	if err != nil {
+		return nil, err
+	}
+	return board.Deduce(), nil
+}
+
+// Deduce returns every hidden cell that is certainly safe or certainly a mine in
+// the order the deductions are made, from the simplest rule to the most
+// involved one. The flags of the player are not trusted, so a flagged cell can
+// be deduced to be safe, whereas a flagged cell deduced to be a mine is not
+// reported again.
+func (board *Board) Deduce() []Deduction {
+	solver := newSolver(board)
+	solver.deduceAll()
+	return solver.deductions
+}
+
+// constraint states that exactly the number of mines are hidden among the cells
+// neighboring the warning number
+type constraint struct {
+	number [2]int
+	cells  [][2]int
+	mines  int
+}
+
+type solver struct {
+	*Board
+	width, height int
+	known         map[[2]int]bool
+	deductions    []Deduction
+}
+
+func newSolver(board *Board) *solver {
+	solver := &solver{Board: board, known: make(map[[2]int]bool)}
+	solver.width = len(board.View)
+	if solver.width > 0 {
+		solver.height = len(board.View[0])
+	}
+	if solver.Topology == nil {
+		solver.Topology = minesweeper.Square
+	}
+	return solver
+}
+
+func (solver *solver) deduceAll() {
+	for solver.deduceSingleCells() || solver.deduceSubsets() || solver.deduceByEnumeration() {
+	}
+}
+
+func (solver *solver) cell(location [2]int) minesweeper.Cell {
+	return solver.View[location[0]][location[1]]
+}
+
+// Reports whether the cell has not been revealed to the player. Flagged and
+// question-marked cells are hidden as well.
+func (solver *solver) hidden(location [2]int) bool {
+	switch solver.cell(location).State {
+	case minesweeper.Hidden, minesweeper.Flagged, minesweeper.QuestionMarked:
+		return true
+	}
+	return false
+}
+
+func (solver *solver) revealedMine(location [2]int) bool {
+	switch solver.cell(location).State {
+	case minesweeper.ExplodedMine, minesweeper.RevealedMine:
+		return true
+	}
+	return false
+}
+
+func (solver *solver) neighbors(location [2]int) [][2]int {
+	grid := minesweeper.Grid{Width: solver.width, Height: solver.height}
+	neighbors := make([][2]int, 0, 8)
+	for _, neighbor := range solver.Topology.Neighbors(grid, location[0], location[1]) {
+		if neighbor[0] >= 0 && neighbor[1] >= 0 && neighbor[0] < solver.width && neighbor[1] < solver.height {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	return neighbors
+}
+
+func (solver *solver) iterate(do func(location [2]int)) {
+	for x := 0; x < solver.width; x++ {
+		for y := 0; y < solver.height; y++ {
+			do([2]int{x, y})
+		}
+	}
+}
+
+// Returns the hidden cells that are neither deduced to be safe nor mines
+func (solver *solver) unknown() [][2]int {
+	unknown := make([][2]int, 0)
+	solver.iterate(func(location [2]int) {
+		if _, known := solver.known[location]; !known && solver.hidden(location) {
+			unknown = append(unknown, location)
+		}
+	})
+	return unknown
+}
+
+// Returns the mines that are neither revealed nor deduced
+func (solver *solver) remainingMines() int {
+	mines := solver.Mines
+	solver.iterate(func(location [2]int) {
+		if solver.known[location] || solver.revealedMine(location) {
+			mines--
+		}
+	})
+	return mines
+}
+
+func (solver *solver) constraints() []*constraint {
+	constraints := make([]*constraint, 0)
+	solver.iterate(func(location [2]int) {
+		cell := solver.cell(location)
+		if cell.State != minesweeper.RevealedNumber && cell.State != minesweeper.RevealedBlank {
+			return
+		}
+		constraint := &constraint{number: location, mines: cell.Value}
+		for _, neighbor := range solver.neighbors(location) {
+			mine, known := solver.known[neighbor]
+			switch {
+			case mine || solver.revealedMine(neighbor):
+				constraint.mines--
+			case !known && solver.hidden(neighbor):
+				constraint.cells = append(constraint.cells, neighbor)
+			}
+		}
+		if len(constraint.cells) > 0 {
+			constraints = append(constraints, constraint)
+		}
+	})
+	return constraints
+}
+
+// Records the deduction of the cell unless it is already known. A flagged cell
+// deduced to be a mine is known without being reported.
+func (solver *solver) deduce(location [2]int, mine bool, rule Rule, numbers [][2]int, reason string) bool {
+	if _, known := solver.known[location]; known {
+		return false
+	}
+	solver.known[location] = mine
+	if mine && solver.cell(location).State == minesweeper.Flagged {
+		return true
+	}
+	solver.deductions = append(solver.deductions, Deduction{
+		X: location[0], Y: location[1], Mine: mine, Rule: rule, Numbers: numbers,
+		Reason: fmt.Sprintf("%v, so %v %v.", reason, describe(location), verdict(mine)),
+	})
+	return true
+}
+
+// A constraint with no remaining mines has all its cells safe, and a constraint
+// with as many remaining mines as its cells has all its cells mined.
+func (solver *solver) deduceSingleCells() bool {
+	var progress bool
+	for _, constraint := range solver.constraints() {
+		number := solver.cell(constraint.number).Value
+		var mine bool
+		var reason string
+		switch {
+		case number == 0:
+			reason = fmt.Sprintf("%v touches no mines", capitalize(solver.describeNumber(constraint.number)))
+		case constraint.mines == 0:
+			reason = fmt.Sprintf("%v already touches %v", capitalize(solver.describeNumber(constraint.number)), count(number, "mine"))
+		case constraint.mines == len(constraint.cells):
+			mine = true
+			reason = fmt.Sprintf("%v has only %v left", capitalize(solver.describeNumber(constraint.number)), count(constraint.mines, "hidden cell"))
+		default:
+			continue
+		}
+		for _, cell := range constraint.cells {
+			progress = solver.deduce(cell, mine, SingleCell, [][2]int{constraint.number}, reason) || progress
+		}
+	}
+	return progress
+}
+
+// When the cells of a constraint are a subset of another constraint's cells, the
+// cells outside the subset must hold the difference of both mine counts.
+func (solver *solver) deduceSubsets() bool {
+	constraints := solver.constraints()
+	for _, subset := range constraints {
+		for _, superset := range constraints {
+			if superset == subset || len(superset.cells) <= len(subset.cells) || !containsAll(superset.cells, subset.cells) {
+				continue
+			}
+			difference := make([][2]int, 0, len(superset.cells))
+			for _, cell := range superset.cells {
+				if !containsAll(subset.cells, [][2]int{cell}) {
+					difference = append(difference, cell)
+				}
+			}
+			mines := superset.mines - subset.mines
+			if mines != 0 && mines != len(difference) {
+				continue
+			}
+			reason := fmt.Sprintf("%v needs %v among the hidden cells it shares with %v, leaving %v for its other hidden cells",
+				capitalize(solver.describeNumber(subset.number)), count(subset.mines, "more mine"),
+				solver.describeNumber(superset.number), count(mines, "mine"))
+			var progress bool
+			for _, cell := range difference {
+				progress = solver.deduce(cell, mines > 0, Subset, [][2]int{subset.number, superset.number}, reason) || progress
+			}
+			if progress {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsAll(cells [][2]int, subset [][2]int) bool {
+	for _, wanted := range subset {
+		found := false
+		for _, cell := range cells {
+			if cell == wanted {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func describe(location [2]int) string {
+	return fmt.Sprintf("(%v, %v)", location[0], location[1])
+}
+
+// Describes the warning number at the location, such as "the 2 at (1, 1)"
+func (solver *solver) describeNumber(location [2]int) string {
+	if solver.cell(location).State == minesweeper.RevealedBlank {
+		return fmt.Sprintf("the blank at %v", describe(location))
+	}
+	return fmt.Sprintf("the %v at %v", solver.cell(location).Value, describe(location))
+}
+
+// Describes the warning numbers, such as "the 1 at (0, 1) and the 2 at (1, 1)"
+func (solver *solver) describeNumbers(locations [][2]int) string {
+	described := make([]string, len(locations))
+	for i, location := range locations {
+		described[i] = solver.describeNumber(location)
+	}
+	if len(described) == 1 {
+		return described[0]
+	}
+	return strings.Join(described[:len(described)-1], ", ") + " and " + described[len(described)-1]
+}
+
+func capitalize(text string) string {
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+func count(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%v %v", n, noun)
+	}
+	return fmt.Sprintf("%v %vs", n, noun)
+}
+
+func verdict(mine bool) string {
+	if mine {
+		return "is a mine"
+	}
+	return "is safe"
+}
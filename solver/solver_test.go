@@ -0,0 +1,274 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package solver
+
+import (
+	"testing"
+
+	"github.com/rrborja/minesweeper"
+	"github.com/rrborja/minesweeper/rendering"
+	"github.com/stretchr/testify/assert"
+)
+
+// Creates the board from the lines of cells with the same x-coordinate, as
+// printed by the rendering.Printer. A hidden cell is '.', a flagged cell is
+// 'F', a revealed mine is '*' and a visited cell is its warning number.
+func newBoard(mines int, lines ...string) *Board {
+	view := make(minesweeper.BoardView, len(lines))
+	for x, line := range lines {
+		view[x] = make([]minesweeper.Cell, len(line))
+		for y, cell := range line {
+			switch {
+			case cell == 'F':
+				view[x][y] = minesweeper.Cell{State: minesweeper.Flagged}
+			case cell == '*':
+				view[x][y] = minesweeper.Cell{State: minesweeper.ExplodedMine}
+			case cell == '0':
+				view[x][y] = minesweeper.Cell{State: minesweeper.RevealedBlank}
+			case cell >= '1' && cell <= '8':
+				view[x][y] = minesweeper.Cell{State: minesweeper.RevealedNumber, Value: int(cell - '0')}
+			}
+		}
+	}
+	return &Board{View: view, Mines: mines}
+}
+
+func TestSingleCellMine(t *testing.T) {
+	deductions := newBoard(1,
+		"11",
+		"1.",
+	).Deduce()
+
+	assert.Equal(t, []Deduction{{
+		X: 1, Y: 1, Mine: true, Rule: SingleCell, Numbers: [][2]int{{0, 0}},
+		Reason: "The 1 at (0, 0) has only 1 hidden cell left, so (1, 1) is a mine.",
+	}}, deductions)
+}
+
+func TestSingleCellSafe(t *testing.T) {
+	deductions := newBoard(1,
+		"1*",
+		"1.",
+		"..",
+	).Deduce()
+
+	assert.Len(t, deductions, 3)
+	for _, deduction := range deductions {
+		assert.False(t, deduction.Mine)
+		assert.Equal(t, SingleCell, deduction.Rule)
+	}
+	assert.Equal(t, "The 1 at (0, 0) already touches 1 mine, so (1, 1) is safe.", deductions[0].Reason)
+}
+
+func TestSubset(t *testing.T) {
+	deductions := newBoard(2,
+		"...",
+		"121",
+	).Deduce()
+
+	assert.Equal(t, []Deduction{
+		{
+			X: 0, Y: 2, Mine: true, Rule: Subset, Numbers: [][2]int{{1, 0}, {1, 1}},
+			Reason: "The 1 at (1, 0) needs 1 more mine among the hidden cells it shares with the 2 at (1, 1), leaving 1 mine for its other hidden cells, so (0, 2) is a mine.",
+		},
+		{
+			X: 0, Y: 1, Mine: false, Rule: SingleCell, Numbers: [][2]int{{1, 2}},
+			Reason: "The 1 at (1, 2) already touches 1 mine, so (0, 1) is safe.",
+		},
+		{
+			X: 0, Y: 0, Mine: true, Rule: SingleCell, Numbers: [][2]int{{1, 0}},
+			Reason: "The 1 at (1, 0) has only 1 hidden cell left, so (0, 0) is a mine.",
+		},
+	}, deductions)
+}
+
+func TestEnumeration(t *testing.T) {
+	deductions := newBoard(5,
+		"....1",
+		"2....",
+		".23.1",
+	).Deduce()
+
+	assert.NotEmpty(t, deductions)
+	assert.Equal(t, Deduction{
+		X: 1, Y: 4, Mine: false, Rule: Enumeration, Numbers: [][2]int{{0, 4}, {1, 0}, {2, 1}, {2, 2}, {2, 4}},
+		Reason: "Every arrangement of mines satisfying the 1 at (0, 4), the 2 at (1, 0), the 2 at (2, 1), the 3 at (2, 2) and the 1 at (2, 4) agrees on that cell, so (1, 4) is safe.",
+	}, deductions[0])
+
+	mines := map[[2]int]bool{{0, 0}: true, {0, 3}: true, {1, 1}: true, {1, 2}: true, {2, 3}: true}
+	for _, deduction := range deductions {
+		assert.Equal(t, mines[[2]int{deduction.X, deduction.Y}], deduction.Mine)
+	}
+}
+
+func TestMineCountSettlesInterior(t *testing.T) {
+	deductions := newBoard(0,
+		"...",
+		"...",
+	).Deduce()
+
+	assert.Len(t, deductions, 6)
+	for _, deduction := range deductions {
+		assert.False(t, deduction.Mine)
+		assert.Equal(t, MineCount, deduction.Rule)
+	}
+	assert.Equal(t, "No mines remain, so (0, 0) is safe.", deductions[0].Reason)
+
+	deductions = newBoard(6,
+		"...",
+		"...",
+	).Deduce()
+	assert.Len(t, deductions, 6)
+	assert.True(t, deductions[0].Mine)
+	assert.Equal(t, "The 6 hidden cells away from the warning numbers must hold 6 of the 6 remaining mines, so (0, 0) is a mine.", deductions[0].Reason)
+}
+
+func TestMineCountRulesOutArrangements(t *testing.T) {
+	deductions := newBoard(1,
+		"1.",
+		"..",
+	).Deduce()
+
+	assert.Len(t, deductions, 0, "The mine can be at any of the 3 hidden cells")
+
+	deductions = newBoard(1,
+		"1..",
+		"...",
+		"...",
+	).Deduce()
+	assert.Len(t, deductions, 5, "The only mine is next to the 1, so the cells away from it are safe")
+	for _, deduction := range deductions {
+		assert.False(t, deduction.Mine)
+	}
+}
+
+func TestFlaggedMineIsNotReported(t *testing.T) {
+	assert.Empty(t, newBoard(1,
+		"11",
+		"1F",
+	).Deduce())
+}
+
+func TestWrongFlagIsDeducedSafe(t *testing.T) {
+	deductions := newBoard(1,
+		"0F",
+		"11",
+		"..",
+	).Deduce()
+
+	assert.Equal(t, Deduction{
+		X: 0, Y: 1, Mine: false, Rule: SingleCell, Numbers: [][2]int{{0, 0}},
+		Reason: "The blank at (0, 0) touches no mines, so (0, 1) is safe.",
+	}, deductions[0])
+}
+
+func TestHexagonalBoard(t *testing.T) {
+	board := newBoard(1,
+		"1.",
+		"..",
+	)
+	board.Topology = minesweeper.Hexagonal
+
+	assert.Equal(t, []Deduction{{
+		X: 1, Y: 1, Mine: false, Rule: MineCount,
+		Reason: "The hidden cells next to the warning numbers must hold the 1 remaining mine, so (1, 1) is safe.",
+	}}, board.Deduce(), "The cell diagonal to the 1 is not its neighbor")
+}
+
+// Plays games by the deductions of the solver only, verifying every deduction
+// against the actual location of the mines.
+func TestSolveIsSound(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		game, _ := minesweeper.NewGame(minesweeper.Intermediate, minesweeper.WithSeed(seed), minesweeper.WithSafeOpening())
+		game.Play()
+		game.Visit(8, 8)
+
+		mines := make(map[[2]int]bool)
+		for _, mine := range game.(rendering.Tracker).BombLocations() {
+			mines[[2]int{mine.X(), mine.Y()}] = true
+		}
+
+		for game.State().Status == minesweeper.Ongoing {
+			deductions, err := Solve(game)
+			assert.NoError(t, err)
+			if len(deductions) == 0 {
+				break
+			}
+			for _, deduction := range deductions {
+				assert.Equal(t, mines[[2]int{deduction.X, deduction.Y}], deduction.Mine, deduction.Reason)
+				if game.State().Status != minesweeper.Ongoing {
+					continue
+				}
+				if deduction.Mine {
+					game.Flag(deduction.X, deduction.Y)
+				} else {
+					_, err := game.Visit(deduction.X, deduction.Y)
+					assert.NoError(t, err)
+				}
+			}
+		}
+		assert.NotEqual(t, minesweeper.Lost, game.State().Status)
+	}
+}
+
+func TestNewBoard(t *testing.T) {
+	game, _ := minesweeper.NewGame(minesweeper.Beginner)
+	game.Play()
+	game.Flag(0, 0)
+
+	board, err := NewBoard(game)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, board.Mines)
+	assert.Equal(t, minesweeper.Square, board.Topology)
+	assert.Equal(t, game.View(), board.View)
+}
+
+func TestNewBoardReadsTopology(t *testing.T) {
+	game, _ := minesweeper.NewGame(minesweeper.Grid{Width: 6, Height: 6}, minesweeper.WithTopology(minesweeper.Hexagonal))
+	game.SetDifficulty(minesweeper.Easy)
+	game.Play()
+
+	board, err := NewBoard(game)
+	assert.NoError(t, err)
+	assert.Equal(t, minesweeper.Hexagonal, board.Topology)
+}
+
+func TestUnsupportedBoards(t *testing.T) {
+	for _, options := range [][]minesweeper.Option{
+		{minesweeper.Grid{Width: 6, Height: 6}, minesweeper.WithWrapAround()},
+		{minesweeper.Grid3D{Width: 6, Height: 6, Depth: 2}},
+		{minesweeper.Grid{Width: 6, Height: 6}, minesweeper.WithMaxMinesPerCell(2)},
+	} {
+		game, _ := minesweeper.NewGame(options...)
+		game.SetDifficulty(minesweeper.Easy)
+		game.Play()
+
+		deductions, err := Solve(game)
+		assert.Empty(t, deductions)
+		assert.IsType(t, &UnsupportedBoardError{}, err)
+
+		probabilities, err := Probabilities(game)
+		assert.Empty(t, probabilities)
+		assert.IsType(t, &UnsupportedBoardError{}, err)
+	}
+
+	_, err := ReadBoard(nil, minesweeper.GameState{WrapAround: true})
+	assert.Equal(t, "Boards whose edges wrap around are not supported by the solver.", err.Error())
+}
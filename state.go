@@ -0,0 +1,200 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import "time"
+
+// Status is the progress of the game.
+// Values of this type are minesweeper.NotStarted, minesweeper.Ongoing,
+// minesweeper.Won and minesweeper.Lost
+type Status uint8
+
+const (
+	// NotStarted is the status of the game whose Play() method has not been
+	// called yet.
+	NotStarted Status = iota
+
+	// Ongoing is the status of the game that is being played.
+	Ongoing
+
+	// Won is the status of the game whose non-mine cells are all visited.
+	Won
+
+	// Lost is the status of the game whose mine has been visited.
+	Lost
+)
+
+// GameState is the snapshot of the game's progress and counters at the time
+// the State() method is called.
+type GameState struct {
+	Status
+
+	// MinesRemaining is the number of mines minus the number of flagged cells.
+	// It can be negative when more cells are flagged than there are mines. A
+	// flag carrying a number of mines counts as many flagged cells.
+	MinesRemaining int
+
+	// FlagsPlaced is the number of flagged cells.
+	FlagsPlaced int
+
+	// UnrevealedSafeCells is the number of non-mine cells left to be visited.
+	UnrevealedSafeCells int
+
+	// Moves is the number of visits and flags that changed the board.
+	Moves int
+
+	// StartTime is the time when the Play() method was called.
+	StartTime time.Time
+
+	// Elapsed is the duration of the game since it was started until it
+	// was won or lost.
+	Elapsed time.Duration
+
+	// Ranked reports whether the game, created with the WithRanking() option,
+	// has never used the Undo() method.
+	Ranked bool
+
+	// Topology defines the neighboring cells of every cell of the board, which
+	// is minesweeper.Square unless the WithTopology(Topology) option is supplied.
+	Topology Topology
+
+	// WrapAround reports whether the edges of the board are connected by the
+	// WithWrapAround() option.
+	WrapAround bool
+
+	// Depth is the number of layers of the board, which is 1 unless the board
+	// is created with the Grid3D option.
+	Depth int
+
+	// MaxMinesPerCell is the number of mines a cell can hold, which is 1 unless
+	// the WithMaxMinesPerCell(int) option is supplied.
+	MaxMinesPerCell int
+}
+
+type timer struct {
+	startTime, endTime time.Time
+}
+
+// tally keeps the counters of the board updated on every move, so the status of
+// the game is decided without iterating the board
+type tally struct {
+	revealed, flags int
+	exploded        bool
+
+	// outcome is the status of the game once it is won or lost, decided only
+	// once so a later move cannot change it
+	outcome Status
+}
+
+func (game *game) State() GameState {
+	if game.Mutex != nil {
+		game.Lock()
+		defer game.Unlock()
+	}
+
+	state := GameState{
+		Status:    game.status(),
+		Moves:     game.moves,
+		StartTime: game.startTime,
+		Elapsed:   game.elapsed(),
+		Ranked:    game.ranked,
+
+		Topology:        game.adjacency(),
+		WrapAround:      game.wrapAround,
+		Depth:           game.layers(),
+		MaxMinesPerCell: game.maxMinesPerCell(),
+	}
+
+	if game.Grid == nil {
+		return state
+	}
+
+	state.FlagsPlaced = game.flags
+	state.MinesRemaining = game.totalBombs() - game.flags
+	state.UnrevealedSafeCells = game.totalNonBombs() - game.revealed
+
+	return state
+}
+
+func (game *game) status() Status {
+	switch {
+	case game.Mutex == nil:
+		return NotStarted
+	case game.outcome != NotStarted:
+		return game.outcome
+	default:
+		return Ongoing
+	}
+}
+
+// Reports whether the game is won or lost
+func (game *game) over() bool {
+	status := game.status()
+	return status == Won || status == Lost
+}
+
+// Returns the status decided by the board: lost once a mine is visited and won
+// once every non-mine cell is visited
+func (game *game) result() Status {
+	switch {
+	case game.exploded:
+		return Lost
+	case game.minesPlaced && game.revealed == game.totalNonBombs():
+		return Won
+	default:
+		return Ongoing
+	}
+}
+
+// Records the outcome of the game, stops the timer and notifies the game's event
+// channel once the game is won or lost
+func (game *game) conclude() {
+	if game.outcome != NotStarted {
+		return
+	}
+	switch game.result() {
+	case Won:
+		game.outcome = Won
+		game.endTime = time.Now()
+		game.notify(Win)
+	case Lost:
+		game.outcome = Lost
+		game.endTime = time.Now()
+		game.notify(Lose)
+	}
+}
+
+func (game *game) notify(event eventType) {
+	select {
+	case game.Event <- event:
+	default:
+	}
+}
+
+func (game *game) elapsed() time.Duration {
+	switch {
+	case game.startTime.IsZero():
+		return 0
+	case game.endTime.IsZero():
+		return time.Since(game.startTime)
+	default:
+		return game.endTime.Sub(game.startTime)
+	}
+}
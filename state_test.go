@@ -0,0 +1,212 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateBeforePlay(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetMineCount(10)
+
+	state := minesweeper.State()
+	assert.Equal(t, NotStarted, state.Status)
+	assert.Equal(t, 10, state.MinesRemaining)
+	assert.Equal(t, sampleGridWidth*sampleGridHeight-10, state.UnrevealedSafeCells)
+	assert.True(t, state.StartTime.IsZero())
+	assert.Equal(t, time.Duration(0), state.Elapsed)
+}
+
+func TestStateReportsBoardConfiguration(t *testing.T) {
+	state := newSampleGame().State()
+	assert.Equal(t, Square, state.Topology)
+	assert.False(t, state.WrapAround)
+	assert.Equal(t, 1, state.Depth)
+	assert.Equal(t, 1, state.MaxMinesPerCell)
+
+	minesweeper, _ := NewGame(Grid3D{4, 4, 3}, WithTopology(Hexagonal), WithWrapAround(), WithMaxMinesPerCell(2))
+	state = minesweeper.State()
+	assert.Equal(t, Hexagonal, state.Topology)
+	assert.True(t, state.WrapAround)
+	assert.Equal(t, 3, state.Depth)
+	assert.Equal(t, 2, state.MaxMinesPerCell)
+}
+
+func TestStateWhenOngoing(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetMineCount(10)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+	var visited int
+	var flagged bool
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node == Number && visited == 0 {
+				blocks, _ := minesweeper.Visit(x, y)
+				visited = len(blocks)
+			} else if block.Node == Bomb && !flagged {
+				minesweeper.Flag(x, y)
+				flagged = true
+			}
+		}
+	}
+
+	state := minesweeper.State()
+	assert.Equal(t, Ongoing, state.Status)
+	assert.Equal(t, 9, state.MinesRemaining)
+	assert.Equal(t, 1, state.FlagsPlaced)
+	assert.Equal(t, sampleGridWidth*sampleGridHeight-10-visited, state.UnrevealedSafeCells)
+	assert.Equal(t, 2, state.Moves)
+	assert.False(t, state.StartTime.IsZero())
+}
+
+func TestStateWhenWon(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node != Bomb && !block.visited {
+				minesweeper.Visit(x, y)
+			}
+		}
+	}
+
+	state := minesweeper.State()
+	assert.Equal(t, Won, state.Status)
+	assert.Equal(t, 0, state.UnrevealedSafeCells)
+	assert.Equal(t, state.Elapsed, minesweeper.State().Elapsed, "Elapsed time must stop when the game ends")
+}
+
+func TestStateWhenLost(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+mainLoop:
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node == Bomb {
+				minesweeper.Visit(x, y)
+				break mainLoop
+			}
+		}
+	}
+
+	state := minesweeper.State()
+	assert.Equal(t, Lost, state.Status)
+	assert.Equal(t, 1, state.Moves)
+	assert.Equal(t, state.Elapsed, minesweeper.State().Elapsed, "Elapsed time must stop when the game ends")
+}
+
+func TestWinIsDecidedWithinVisit(t *testing.T) {
+	minesweeper, event := NewGame(Grid{sampleGridWidth, sampleGridHeight})
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node != Bomb && !block.visited {
+				assert.Equal(t, Ongoing, minesweeper.State().Status)
+				assert.Empty(t, event)
+				minesweeper.Visit(x, y)
+			}
+		}
+	}
+
+	assert.Equal(t, Won, minesweeper.State().Status)
+	select {
+	case won := <-event:
+		assert.Equal(t, Win, won)
+	default:
+		assert.Fail(t, "Was expecting the winning event as soon as the last cell is visited")
+	}
+}
+
+func TestLossIsDecidedWithinVisit(t *testing.T) {
+	minesweeper, event := NewGame(Grid{sampleGridWidth, sampleGridHeight})
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node == Bomb {
+				minesweeper.Visit(x, y)
+			}
+		}
+	}
+
+	assert.Equal(t, Lost, minesweeper.State().Status)
+	assert.Equal(t, Lose, <-event)
+	assert.Empty(t, event, "The losing event must only be notified once")
+}
+
+func TestVisitAfterWin(t *testing.T) {
+	minesweeper := newGameWithMines([]Option{Grid{3, 3}}, [2]int{2, 2})
+	minesweeper.Visit(0, 0)
+	assert.Equal(t, Won, minesweeper.State().Status)
+
+	blocks, err := minesweeper.Visit(2, 2)
+	assert.Empty(t, blocks)
+	assert.IsType(t, new(GameOverError), err)
+	assert.EqualError(t, err, "The game is already won.")
+
+	state := minesweeper.State()
+	assert.Equal(t, Won, state.Status, "Visiting a mine after winning must not lose the game")
+	assert.Equal(t, 1, state.Moves)
+}
+
+func TestMovesAfterLoss(t *testing.T) {
+	minesweeper := newGameWithMines([]Option{Grid{3, 3}}, [2]int{2, 2})
+	minesweeper.Visit(2, 2)
+	assert.Equal(t, Lost, minesweeper.State().Status)
+
+	blocks, err := minesweeper.Visit(0, 0)
+	assert.Empty(t, blocks)
+	assert.IsType(t, new(GameOverError), err)
+	assert.EqualError(t, err, "The game is already lost.")
+
+	assert.IsType(t, new(GameOverError), minesweeper.Flag(1, 1))
+
+	result, err := minesweeper.Chord(2, 2)
+	assert.False(t, result.Accepted)
+	assert.IsType(t, new(GameOverError), err)
+
+	state := minesweeper.State()
+	assert.Equal(t, Lost, state.Status)
+	assert.Equal(t, 1, state.Moves)
+	assert.Equal(t, 0, state.FlagsPlaced)
+	assert.Equal(t, 8, state.UnrevealedSafeCells)
+}
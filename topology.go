@@ -0,0 +1,208 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+// Topology defines which cells of the grid are neighbors of each other. The
+// neighboring cells of a cell are the cells counted by its warning number,
+// automatically visited around a cell with no warning number and visited when
+// the cell is chorded. Built-in topologies are minesweeper.Square and
+// minesweeper.Hexagonal
+type Topology interface {
+	// Neighbors returns the xy-coordinates of the neighboring cells of the cell
+	// at the xy-coordinate. Coordinates outside the grid are ignored by the game.
+	Neighbors(grid Grid, x, y int) [][2]int
+}
+
+type squareTopology struct{}
+
+type hexagonalTopology struct{}
+
+var (
+	// Square is the topology of the classic board where every cell has up to 8
+	// neighboring cells, horizontally, vertically and diagonally. This is the
+	// topology of the game unless the WithTopology(Topology) option is supplied.
+	Square Topology = squareTopology{}
+
+	// Hexagonal is the topology of the board of hexagonal cells where every cell
+	// has up to 6 neighboring cells. The cells are laid out in lines of the same
+	// x-coordinate, as printed by the rendering.Printer, and every line of an odd
+	// x-coordinate is shifted half a cell towards the greater y-coordinates.
+	Hexagonal Topology = hexagonalTopology{}
+)
+
+// Neighborhood is the Topology whose neighboring cells are at the same relative
+// xy-offsets from every cell, such as {1, 2} for the cell one line after and two
+// cells after. Minesweeper variants count the mines within different rules of
+// adjacency, like the moves of a chess knight.
+type Neighborhood [][2]int
+
+var (
+	// Orthogonal is the neighborhood of 4 cells horizontally and vertically
+	// adjacent to the cell.
+	Orthogonal = Neighborhood{{0, -1}, {-1, 0}, {1, 0}, {0, 1}}
+
+	// Standard is the neighborhood of 8 cells surrounding the cell, the same
+	// cells as the minesweeper.Square topology.
+	Standard = Neighborhood{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}, {1, 0}, {-1, 1}, {0, 1}, {1, 1}}
+
+	// Radius2 is the neighborhood of 24 cells within a distance of two cells
+	// horizontally and vertically from the cell.
+	Radius2 = radius(2)
+
+	// KnightMove is the neighborhood of 8 cells a chess knight can move to from
+	// the cell.
+	KnightMove = Neighborhood{{-1, -2}, {1, -2}, {-2, -1}, {2, -1}, {-2, 1}, {2, 1}, {-1, 2}, {1, 2}}
+)
+
+func radius(distance int) Neighborhood {
+	neighborhood := make(Neighborhood, 0)
+	for dy := -distance; dy <= distance; dy++ {
+		for dx := -distance; dx <= distance; dx++ {
+			if dx != 0 || dy != 0 {
+				neighborhood = append(neighborhood, [2]int{dx, dy})
+			}
+		}
+	}
+	return neighborhood
+}
+
+var topologies = map[string]Topology{
+	"square":    Square,
+	"hexagonal": Hexagonal,
+}
+
+func (squareTopology) Neighbors(grid Grid, x, y int) [][2]int {
+	return [][2]int{
+		{x - 1, y - 1}, {x, y - 1}, {x + 1, y - 1},
+		{x - 1, y}, {x + 1, y},
+		{x - 1, y + 1}, {x, y + 1}, {x + 1, y + 1},
+	}
+}
+
+func (hexagonalTopology) Neighbors(grid Grid, x, y int) [][2]int {
+	shift := x % 2
+	return [][2]int{
+		{x - 1, y - 1 + shift}, {x - 1, y + shift},
+		{x, y - 1}, {x, y + 1},
+		{x + 1, y - 1 + shift}, {x + 1, y + shift},
+	}
+}
+
+func (neighborhood Neighborhood) Neighbors(grid Grid, x, y int) [][2]int {
+	neighbors := make([][2]int, 0, len(neighborhood))
+	for _, offset := range neighborhood {
+		if offset != [2]int{0, 0} {
+			neighbors = append(neighbors, [2]int{x + offset[0], y + offset[1]})
+		}
+	}
+	return neighbors
+}
+
+// WithTopology sets the topology of the board that defines the neighboring cells
+// of every cell, such as the minesweeper.Hexagonal topology or a Neighborhood.
+func WithTopology(topology Topology) Option {
+	return optionFunc(func(game *game) {
+		game.topology = topology
+	})
+}
+
+// WithWrapAround connects the left edge of the board to its right edge and the
+// top edge to its bottom edge, so the board becomes a torus and no cell is on
+// an edge. On a board of the minesweeper.Square topology of at least 3x3 cells,
+// every cell has exactly 8 neighboring cells. A board of the
+// minesweeper.Hexagonal topology must have an even width to wrap around, since
+// its lines are shifted alternately, otherwise the Play() method returns an
+// OddWrapAroundWidthError.
+func WithWrapAround() Option {
+	return optionFunc(func(game *game) {
+		game.wrapAround = true
+	})
+}
+
+// Returns the neighbors of the block at the row of the game.blocks array, which
+// continues across the layers of a board created with the Grid3D option.
+func (game *game) neighbors(x, row int) [][2]int {
+	y, z := row%game.Height, row/game.Height
+	neighbors := game.adjacency().Neighbors(*game.Grid, x, y)
+	if game.wrapAround {
+		neighbors = game.wrap(x, y, neighbors)
+	}
+	if game.layers() > 1 {
+		neighbors = game.stack(x, y, z, neighbors)
+	}
+	return neighbors
+}
+
+// Returns the topology of the board, which is minesweeper.Square unless the
+// WithTopology(Topology) option is supplied
+func (game *game) adjacency() Topology {
+	if game.topology == nil {
+		return Square
+	}
+	return game.topology
+}
+
+// Extends the neighbors of the cell in its layer to the adjacent layers, where
+// the cell itself and its neighbors are also neighboring cells, giving 26
+// neighbors to a cell of the minesweeper.Square topology. The first and the
+// last layers are not connected, even with the WithWrapAround() option.
+func (game *game) stack(x, y, z int, neighbors [][2]int) [][2]int {
+	stacked := make([][2]int, 0, 3*len(neighbors)+2)
+	for layer := z - 1; layer <= z+1; layer++ {
+		if layer < 0 || layer >= game.layers() {
+			continue
+		}
+		if layer != z {
+			stacked = append(stacked, [2]int{x, layer*game.Height + y})
+		}
+		for _, neighbor := range neighbors {
+			if neighbor[1] >= 0 && neighbor[1] < game.Height {
+				stacked = append(stacked, [2]int{neighbor[0], layer*game.Height + neighbor[1]})
+			}
+		}
+	}
+	return stacked
+}
+
+// Moves the neighbors outside the grid to the opposite edges. A small grid may
+// wrap a neighbor onto the cell itself or onto another neighbor, which are
+// discarded.
+func (game *game) wrap(x, y int, neighbors [][2]int) [][2]int {
+	wrapped := make([][2]int, 0, len(neighbors))
+	seen := map[[2]int]bool{{x, y}: true}
+	for _, neighbor := range neighbors {
+		neighbor[0] = (neighbor[0]%game.Width + game.Width) % game.Width
+		neighbor[1] = (neighbor[1]%game.Height + game.Height) % game.Height
+		if !seen[neighbor] {
+			seen[neighbor] = true
+			wrapped = append(wrapped, neighbor)
+		}
+	}
+	return wrapped
+}
+
+func topologyName(topology Topology) string {
+	for name, builtIn := range topologies {
+		if topology == builtIn {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,362 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import (
+	"testing"
+
+	"github.com/rrborja/minesweeper/visited"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSquareIsTheDefaultTopology(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{5, 5})
+	game := minesweeper.(*game)
+
+	assert.Equal(t, Square.Neighbors(*game.Grid, 2, 2), game.neighbors(2, 2))
+}
+
+func TestHexagonalNeighbors(t *testing.T) {
+	grid := Grid{6, 6}
+	for x := 1; x < grid.Width-1; x++ {
+		for y := 1; y < grid.Height-1; y++ {
+			neighbors := Hexagonal.Neighbors(grid, x, y)
+			assert.Len(t, neighbors, 6)
+			for _, neighbor := range neighbors {
+				assert.Contains(t, Hexagonal.Neighbors(grid, neighbor[0], neighbor[1]), [2]int{x, y},
+					"Every cell must be a neighbor of its neighbors")
+			}
+		}
+	}
+}
+
+func TestHexagonalHints(t *testing.T) {
+	minesweeper := newGameWithMines([]Option{Grid{5, 5}, WithTopology(Hexagonal)}, [2]int{2, 2})
+	game := minesweeper.(*game)
+
+	var hints int
+	game.iterateBlocks(func(block *Block) bool {
+		if block.Node == Number {
+			assert.Equal(t, 1, block.Value)
+			hints++
+		}
+		return true
+	})
+	assert.Equal(t, 6, hints)
+	assert.Equal(t, Unknown, game.blocks[1][3].Node, "The diagonal cell across the even line is not a neighbor")
+	assert.Equal(t, Unknown, game.blocks[3][3].Node, "The diagonal cell across the even line is not a neighbor")
+}
+
+func TestHexagonalFloodFill(t *testing.T) {
+	minesweeper := newGameWithMines([]Option{Grid{5, 5}, WithTopology(Hexagonal)}, [2]int{4, 4})
+
+	_, err := minesweeper.Visit(0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, Won, minesweeper.State().Status)
+	assert.False(t, minesweeper.(*game).blocks[4][4].visited)
+}
+
+func TestHexagonalChord(t *testing.T) {
+	minesweeper := newGameWithMines([]Option{Grid{5, 5}, WithTopology(Hexagonal)}, [2]int{1, 1}, [2]int{1, 3})
+	minesweeper.Visit(2, 2)
+	minesweeper.Flag(1, 1)
+
+	result, err := minesweeper.Chord(2, 2)
+	assert.NoError(t, err)
+	assert.True(t, result.Accepted, "The mine at the diagonal across the even line is not counted")
+	assert.Empty(t, result.Exploded)
+	assert.False(t, minesweeper.(*game).blocks[1][3].visited)
+}
+
+func TestSnapshotKeepsTopology(t *testing.T) {
+	minesweeper := newGameWithMines([]Option{Grid{5, 5}, WithTopology(Hexagonal)}, [2]int{2, 2})
+	minesweeper.Visit(0, 0)
+
+	snapshot := minesweeper.Snapshot()
+	assert.Equal(t, "hexagonal", snapshot.Topology)
+
+	loaded, _, err := LoadGame(snapshot)
+	assert.NoError(t, err)
+	assert.Equal(t, Hexagonal, loaded.(*game).topology)
+	assertSameGame(t, minesweeper, loaded)
+}
+
+func TestWrapAroundNeighbors(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{5, 4}, WithWrapAround())
+	game := minesweeper.(*game)
+
+	game.iterateBlocks(func(block *Block) bool {
+		var neighbors int
+		game.traverseAdjacentCells(block.X(), block.Y(), func(*Block) {
+			neighbors++
+		})
+		assert.Equal(t, 8, neighbors)
+		return true
+	})
+	assert.Contains(t, game.neighbors(0, 0), [2]int{4, 3})
+	assert.Contains(t, game.neighbors(4, 3), [2]int{0, 0})
+}
+
+func TestWrapAroundSmallGrid(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{2, 2}, WithWrapAround())
+	game := minesweeper.(*game)
+
+	neighbors := game.neighbors(0, 0)
+	assert.Len(t, neighbors, 3, "Neighbors wrapped onto the cell itself or onto each other are discarded")
+	assert.Contains(t, neighbors, [2]int{1, 0})
+	assert.Contains(t, neighbors, [2]int{0, 1})
+	assert.Contains(t, neighbors, [2]int{1, 1})
+}
+
+func TestHexagonalWrapAroundNeighbors(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{6, 5}, WithTopology(Hexagonal), WithWrapAround())
+	game := minesweeper.(*game)
+
+	for x := 0; x < game.Width; x++ {
+		for y := 0; y < game.Height; y++ {
+			neighbors := game.neighbors(x, y)
+			assert.Len(t, neighbors, 6)
+			for _, neighbor := range neighbors {
+				assert.Contains(t, game.neighbors(neighbor[0], neighbor[1]), [2]int{x, y},
+					"Every cell must be a neighbor of its neighbors across the edges")
+			}
+		}
+	}
+}
+
+func TestHexagonalWrapAroundOddWidth(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{5, 6}, WithTopology(Hexagonal), WithWrapAround())
+	minesweeper.SetDifficulty(Easy)
+
+	err := minesweeper.Play()
+	assert.IsType(t, new(OddWrapAroundWidthError), err)
+	assert.Equal(t, NotStarted, minesweeper.State().Status)
+}
+
+func TestWrapAroundTalliedBomb(t *testing.T) {
+	testTalliedBomb(t, WithWrapAround())
+}
+
+func TestWrapAroundVisitedUnmarkedBlockDistributeVisit(t *testing.T) {
+	testVisitedUnmarkedBlockDistributeVisit(t, WithWrapAround())
+}
+
+func TestWrapAroundVisitedBlockWhenBlockIsUnknownAndSpreadVisits(t *testing.T) {
+	testVisitedBlockWhenBlockIsUnknownAndSpreadVisits(t, WithWrapAround())
+}
+
+func TestWrapAroundCheckEventOfGameWhenWinning(t *testing.T) {
+	testCheckEventOfGameWhenWinning(t, WithWrapAround())
+}
+
+func TestWrapAroundAutoVisitNeighboringUnprobedNumbersAfterMineFlagged(t *testing.T) {
+	testAutoVisitNeighboringUnprobedNumbersAfterMineFlagged(t, WithWrapAround())
+}
+
+func TestWrapAroundAutoVisitWithWronglyFlaggedBomb(t *testing.T) {
+	testAutoVisitWithWronglyFlaggedBomb(t, WithWrapAround())
+}
+
+func TestWrapAroundDoNotAutoVisitIfAllNeighboringBombsAreNotFlagged(t *testing.T) {
+	testDoNotAutoVisitIfAllNeighboringBombsAreNotFlagged(t, WithWrapAround())
+}
+
+func TestWrapAroundFloodFill(t *testing.T) {
+	minesweeper := newGameWithMines([]Option{Grid{5, 5}, WithWrapAround()}, [2]int{0, 0})
+	game := minesweeper.(*game)
+
+	assert.Equal(t, 1, game.blocks[4][4].Value, "The corner across the board is a neighbor of the mine")
+
+	_, err := minesweeper.Visit(2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, Won, minesweeper.State().Status)
+}
+
+func TestWrapAroundChord(t *testing.T) {
+	minesweeper := newGameWithMines([]Option{Grid{5, 5}, WithWrapAround()}, [2]int{4, 4}, [2]int{2, 2})
+	game := minesweeper.(*game)
+
+	minesweeper.Visit(0, 0)
+	minesweeper.Flag(4, 4)
+
+	result, err := minesweeper.Chord(0, 0)
+	assert.NoError(t, err)
+	assert.True(t, result.Accepted, "The flag across the board is counted")
+	assert.Empty(t, result.Exploded)
+	assert.True(t, game.blocks[4][0].visited)
+	assert.True(t, game.blocks[0][4].visited)
+}
+
+func TestSnapshotKeepsWrapAround(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{5, 5}, WithWrapAround())
+	minesweeper.SetMineCount(3)
+	minesweeper.Play()
+
+	loaded, _, err := LoadGame(minesweeper.Snapshot())
+	assert.NoError(t, err)
+	assert.True(t, loaded.(*game).wrapAround)
+	assertSameGame(t, minesweeper, loaded)
+}
+
+func TestBuiltInNeighborhoods(t *testing.T) {
+	grid := Grid{9, 9}
+	assert.Len(t, Orthogonal.Neighbors(grid, 4, 4), 4)
+	assert.Len(t, Standard.Neighbors(grid, 4, 4), 8)
+	assert.Len(t, Radius2.Neighbors(grid, 4, 4), 24)
+	assert.Len(t, KnightMove.Neighbors(grid, 4, 4), 8)
+	assert.Equal(t, Square.Neighbors(grid, 4, 4), Standard.Neighbors(grid, 4, 4))
+	assert.Contains(t, KnightMove.Neighbors(grid, 4, 4), [2]int{5, 6})
+	assert.NotContains(t, Radius2.Neighbors(grid, 4, 4), [2]int{4, 4})
+}
+
+func TestNeighborhoodIgnoresTheCellItself(t *testing.T) {
+	neighborhood := Neighborhood{{0, 0}, {1, 0}}
+	assert.Equal(t, [][2]int{{3, 2}}, neighborhood.Neighbors(Grid{5, 5}, 2, 2))
+}
+
+func TestKnightMoveHints(t *testing.T) {
+	minesweeper := newGameWithMines([]Option{Grid{5, 5}, WithTopology(KnightMove)}, [2]int{2, 2})
+	game := minesweeper.(*game)
+
+	assert.Equal(t, 1, game.blocks[0][1].Value)
+	assert.Equal(t, 1, game.blocks[4][3].Value)
+	assert.Equal(t, Unknown, game.blocks[1][1].Node, "The adjacent cell is not a knight's move away")
+}
+
+func TestOrthogonalFloodFill(t *testing.T) {
+	minesweeper := newGameWithMines([]Option{Grid{5, 5}, WithTopology(Orthogonal)}, [2]int{1, 1})
+
+	blocks, err := minesweeper.Visit(0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, blocks, 3, "The corner diagonal to the mine is walled in by two warning numbers")
+	assert.Equal(t, Ongoing, minesweeper.State().Status)
+
+	minesweeper.Visit(4, 4)
+	assert.Equal(t, Won, minesweeper.State().Status)
+}
+
+func TestRadius2Chord(t *testing.T) {
+	minesweeper := newGameWithMines([]Option{Grid{5, 5}, WithTopology(Radius2)}, [2]int{0, 0}, [2]int{4, 4})
+	minesweeper.Visit(2, 2)
+	minesweeper.Flag(0, 0)
+
+	result, err := minesweeper.Chord(2, 2)
+	assert.NoError(t, err)
+	assert.False(t, result.Accepted, "Both mines are two cells away from the chorded cell")
+
+	minesweeper.Flag(4, 4)
+	result, err = minesweeper.Chord(2, 2)
+	assert.NoError(t, err)
+	assert.True(t, result.Accepted)
+	assert.Equal(t, Won, minesweeper.State().Status)
+}
+
+func TestSnapshotKeepsNeighborhood(t *testing.T) {
+	minesweeper := newGameWithMines([]Option{Grid{5, 5}, WithTopology(KnightMove)}, [2]int{2, 2})
+	minesweeper.Visit(0, 1)
+
+	snapshot := minesweeper.Snapshot()
+	assert.Equal(t, [][2]int(KnightMove), snapshot.Neighborhood)
+
+	loaded, _, err := LoadGame(snapshot)
+	assert.NoError(t, err)
+	assert.Equal(t, KnightMove, loaded.(*game).topology)
+	assertSameGame(t, minesweeper, loaded)
+}
+
+// Creates a started 3x3x3 board with mines at the given xyz-coordinates.
+func newLayeredGame(mines ...[3]int) Minesweeper {
+	stacked := make([][2]int, len(mines))
+	for i, mine := range mines {
+		stacked[i] = [2]int{mine[0], mine[2]*3 + mine[1]}
+	}
+	return newGameWithMines([]Option{Grid3D{3, 3, 3}}, stacked...)
+}
+
+func TestLayeredNeighbors(t *testing.T) {
+	minesweeper := newLayeredGame()
+	game := minesweeper.(*game)
+
+	count := func(x, y, z int) (neighbors int) {
+		game.traverseAdjacentCells(x, game.row(game.block(x, y, z)), func(cell *Block) {
+			assert.False(t, cell.X() == x && cell.Y() == y && cell.Z() == z, "A cell is never a neighbor of itself")
+			neighbors++
+		})
+		return
+	}
+	assert.Equal(t, 26, count(1, 1, 1))
+	assert.Equal(t, 7, count(0, 0, 0))
+	assert.Equal(t, 17, count(1, 1, 0))
+}
+
+func TestLayeredHints(t *testing.T) {
+	minesweeper := newLayeredGame([3]int{1, 1, 1})
+	game := minesweeper.(*game)
+
+	var hints int
+	game.iterateBlocks(func(block *Block) bool {
+		assert.Equal(t, block.Node == Number, !(block.X() == 1 && block.Y() == 1 && block.Z() == 1))
+		if block.Node == Number {
+			hints++
+		}
+		return true
+	})
+	assert.Equal(t, 26, hints)
+}
+
+func TestLayeredFloodFill(t *testing.T) {
+	minesweeper := newLayeredGame([3]int{2, 2, 2})
+
+	blocks, err := minesweeper.Visit3D(0, 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, blocks, 26)
+	assert.Equal(t, Won, minesweeper.State().Status)
+}
+
+func TestLayeredVisitOutOfBounds(t *testing.T) {
+	minesweeper := newLayeredGame([3]int{2, 2, 2})
+
+	_, err := minesweeper.Visit3D(0, 0, 3)
+	assert.Equal(t, &OutOfBoundsError{x: 0, y: 0, z: 3, grid: Grid{3, 3}, depth: 3}, err)
+	assert.Error(t, minesweeper.Flag3D(0, 3, 0))
+	_, err = minesweeper.Chord3D(-1, 0, 0)
+	assert.Error(t, err)
+}
+
+func TestLayeredExplosion(t *testing.T) {
+	minesweeper := newLayeredGame([3]int{2, 2, 2})
+
+	blocks, err := minesweeper.Visit3D(2, 2, 2)
+	assert.Equal(t, &ExplodedError{x: 2, y: 2, z: 2}, err)
+	assert.Equal(t, 2, blocks[0].Z())
+	assert.Equal(t, visited.Bomb, minesweeper.(*game).LastAction().Action)
+	assert.Equal(t, 2, minesweeper.(*game).LastAction().Z())
+}
+
+func TestSnapshotKeepsLayers(t *testing.T) {
+	minesweeper := newLayeredGame([3]int{2, 2, 2}, [3]int{0, 0, 1})
+	minesweeper.Flag3D(2, 2, 2)
+	minesweeper.Visit3D(1, 1, 2)
+
+	loaded, _, err := LoadGame(minesweeper.Snapshot())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, loaded.(*game).depth)
+	assertSameGame(t, minesweeper, loaded)
+}
@@ -0,0 +1,152 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import (
+	"time"
+
+	"github.com/rrborja/minesweeper/visited"
+)
+
+// mark is the visited, flagged and question-marked state of a block along with
+// the number of mines carried by its flag
+type mark struct {
+	visited, flagged, questioned bool
+	flags                        int
+}
+
+// change is the mark of a block before and after a move
+type change struct {
+	block         *Block
+	before, after mark
+}
+
+// moveState is the part of the game's state that a move changes besides the
+// marks of the blocks
+type moveState struct {
+	history *visited.History
+	tally
+	moves   int
+	endTime time.Time
+}
+
+// move is a visit, flag or chord that can be undone and redone
+type move struct {
+	x, y, z       int
+	changes       []change
+	before, after moveState
+}
+
+func (game *game) Undo() error {
+	if game.Mutex != nil {
+		game.Lock()
+		defer game.Unlock()
+	}
+
+	if len(game.undoable) == 0 {
+		return new(NothingToUndoError)
+	}
+
+	move := game.undoable[len(game.undoable)-1]
+	game.undoable = game.undoable[:len(game.undoable)-1]
+
+	// In reverse order, so a block changed more than once by the move gets its
+	// mark from before the move back
+	for i := len(move.changes) - 1; i >= 0; i-- {
+		move.changes[i].block.restore(move.changes[i].before)
+	}
+	game.restore(move.before)
+	game.redoable = append(game.redoable, move)
+	game.ranked = false
+
+	game.publish(GameEvent{Kind: MoveUndone, X: move.x, Y: move.y, Z: move.z, Blocks: move.blocks()})
+	return nil
+}
+
+func (game *game) Redo() error {
+	if game.Mutex != nil {
+		game.Lock()
+		defer game.Unlock()
+	}
+
+	if len(game.redoable) == 0 {
+		return new(NothingToRedoError)
+	}
+
+	move := game.redoable[len(game.redoable)-1]
+	game.redoable = game.redoable[:len(game.redoable)-1]
+
+	for _, change := range move.changes {
+		change.block.restore(change.after)
+	}
+	game.restore(move.after)
+	game.undoable = append(game.undoable, move)
+
+	game.publish(GameEvent{Kind: MoveRedone, X: move.x, Y: move.y, Z: move.z, Blocks: move.blocks()})
+	return nil
+}
+
+func (game *game) moveState() moveState {
+	return moveState{history: game.recordedActions.History, tally: game.tally, moves: game.moves, endTime: game.endTime}
+}
+
+func (game *game) restore(state moveState) {
+	game.recordedActions.History = state.history
+	game.tally = state.tally
+	game.moves = state.moves
+	game.endTime = state.endTime
+}
+
+// Keeps the mark of the block before the move in progress changes it, so only
+// the blocks touched by the move are remembered
+func (game *game) touch(block *Block) {
+	game.changes = append(game.changes, change{block: block, before: block.mark()})
+}
+
+// Remembers the move made at the xyz-coordinates from the marks of the blocks
+// it touched and the state of the game before the move. Any move previously
+// undone can no longer be redone.
+func (game *game) remember(x, y, z int, before moveState) {
+	move := &move{x: x, y: y, z: z, changes: game.changes, before: before, after: game.moveState()}
+	for i := range move.changes {
+		move.changes[i].after = move.changes[i].block.mark()
+	}
+	game.changes = nil
+
+	game.undoable = append(game.undoable, move)
+	game.redoable = nil
+}
+
+func (block *Block) mark() mark {
+	return mark{block.visited, block.flagged, block.questioned, block.flags}
+}
+
+func (block *Block) restore(mark mark) {
+	block.visited, block.flagged, block.questioned = mark.visited, mark.flagged, mark.questioned
+	block.flags = mark.flags
+}
+
+func (move *move) blocks() []Block {
+	blocks := make([]Block, len(move.changes))
+	for i, change := range move.changes {
+		blocks[i] = *change.block
+	}
+	return blocks
+}
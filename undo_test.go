@@ -0,0 +1,240 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndoWithoutMoves(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	assert.Equal(t, new(NothingToUndoError), minesweeper.Undo())
+	assert.Equal(t, new(NothingToRedoError), minesweeper.Redo())
+}
+
+func TestUndoFlag(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	minesweeper.Flag(3, 6)
+	assert.NoError(t, minesweeper.Undo())
+
+	game := minesweeper.(*game)
+	assert.False(t, game.blocks[3][6].flagged)
+	assert.Equal(t, 0, minesweeper.State().FlagsPlaced)
+
+	assert.NoError(t, minesweeper.Redo())
+	assert.True(t, game.blocks[3][6].flagged)
+	assert.Equal(t, 1, minesweeper.State().FlagsPlaced)
+}
+
+func TestUndoAutoRevealedBlocks(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+mainLoop:
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node == Unknown {
+				blocks, _ := minesweeper.Visit(x, y)
+				assert.True(t, len(blocks) > 1)
+				history := game.History()
+
+				assert.NoError(t, minesweeper.Undo())
+				for _, block := range blocks {
+					assert.False(t, game.blocks[block.X()][block.Y()].visited)
+				}
+				assert.Nil(t, game.History())
+				assert.Equal(t, sampleGridWidth*sampleGridHeight-game.totalBombs(), minesweeper.State().UnrevealedSafeCells)
+
+				assert.NoError(t, minesweeper.Redo())
+				for _, block := range blocks {
+					assert.True(t, game.blocks[block.X()][block.Y()].visited)
+				}
+				assert.Equal(t, history, game.History())
+				break mainLoop
+			}
+		}
+	}
+}
+
+func TestUndoFatalMove(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+mainLoop:
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node == Bomb {
+				minesweeper.Visit(x, y)
+				assert.Equal(t, Lost, minesweeper.State().Status)
+
+				assert.NoError(t, minesweeper.Undo())
+				assert.Equal(t, Ongoing, minesweeper.State().Status)
+				assert.False(t, game.blocks[x][y].visited)
+
+				assert.NoError(t, minesweeper.Redo())
+				assert.Equal(t, Lost, minesweeper.State().Status)
+				break mainLoop
+			}
+		}
+	}
+}
+
+func TestUndoChord(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node == Number && !block.visited {
+				minesweeper.Visit(x, y)
+				game.traverseAdjacentCells(x, y, func(cell *Block) {
+					if cell.Node == Bomb && !cell.flagged {
+						minesweeper.Flag(cell.X(), cell.Y())
+					}
+				})
+
+				blocks, _ := minesweeper.Visit(x, y)
+				if len(blocks) == 0 {
+					continue
+				}
+
+				assert.NoError(t, minesweeper.Undo())
+				for _, block := range blocks {
+					assert.False(t, game.blocks[block.X()][block.Y()].visited)
+				}
+				game.traverseAdjacentCells(x, y, func(cell *Block) {
+					if cell.Node == Bomb {
+						assert.True(t, cell.flagged, "Undoing a chord must not undo the flags")
+					}
+				})
+				assert.True(t, game.blocks[x][y].visited)
+				return
+			}
+		}
+	}
+}
+
+func TestNewMoveDiscardsRedo(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	minesweeper.Flag(0, 0)
+	minesweeper.Undo()
+	minesweeper.Flag(1, 1)
+
+	assert.Equal(t, new(NothingToRedoError), minesweeper.Redo())
+}
+
+func TestUndoMakesGameUnranked(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithRanking())
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	minesweeper.Flag(0, 0)
+	assert.True(t, minesweeper.State().Ranked)
+
+	minesweeper.Undo()
+	assert.False(t, minesweeper.State().Ranked)
+
+	minesweeper.Redo()
+	assert.False(t, minesweeper.State().Ranked)
+}
+
+func TestUndoQuestionMark(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithQuestionMarks())
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	minesweeper.Flag(3, 6)
+	minesweeper.Flag(3, 6)
+	minesweeper.Undo()
+
+	block := minesweeper.(*game).blocks[3][6]
+	assert.True(t, block.Flagged())
+	assert.False(t, block.QuestionMarked())
+	assert.Equal(t, 1, minesweeper.State().FlagsPlaced)
+}
+
+func TestUndoRestoresMoves(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	minesweeper.Flag(0, 0)
+	minesweeper.Flag(1, 1)
+	assert.Equal(t, 2, minesweeper.State().Moves)
+
+	minesweeper.Undo()
+	assert.Equal(t, 1, minesweeper.State().Moves)
+
+	minesweeper.Redo()
+	assert.Equal(t, 2, minesweeper.State().Moves)
+}
+
+func TestUndoRestoresQuestionMarkOfRevealedBlock(t *testing.T) {
+	minesweeper := newGameWithMines([]Option{Grid{5, 5}, WithQuestionMarks()}, [2]int{4, 4})
+	game := minesweeper.(*game)
+
+	minesweeper.Flag(0, 4)
+	minesweeper.Flag(0, 4)
+	assert.True(t, game.blocks[0][4].questioned)
+
+	minesweeper.Visit(0, 0)
+	assert.True(t, game.blocks[0][4].visited)
+	assert.False(t, game.blocks[0][4].questioned)
+
+	assert.NoError(t, minesweeper.Undo())
+	assert.False(t, game.blocks[0][4].visited)
+	assert.True(t, game.blocks[0][4].questioned)
+	assert.False(t, game.blocks[0][0].visited)
+
+	assert.NoError(t, minesweeper.Redo())
+	assert.True(t, game.blocks[0][4].visited)
+	assert.False(t, game.blocks[0][4].questioned)
+}
+
+func TestUndoRemembersOnlyTouchedBlocks(t *testing.T) {
+	minesweeper := newGameWithMines([]Option{Grid{5, 5}}, [2]int{4, 4})
+	game := minesweeper.(*game)
+
+	minesweeper.Flag(4, 4)
+	assert.Len(t, game.undoable[0].changes, 1)
+
+	minesweeper.Visit(3, 3)
+	assert.Len(t, game.undoable[1].changes, 1)
+}
@@ -0,0 +1,132 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+// CellState is the state of a cell as observed by the player.
+// Values of this type are minesweeper.Hidden, minesweeper.Flagged,
+// minesweeper.RevealedBlank, minesweeper.RevealedNumber,
+// minesweeper.ExplodedMine, minesweeper.RevealedMine,
+// minesweeper.WrongFlag and minesweeper.QuestionMarked
+type CellState uint8
+
+const (
+	// Hidden is the state of a cell that is neither visited nor flagged.
+	Hidden CellState = iota
+
+	// Flagged is the state of a cell flagged by the player.
+	Flagged
+
+	// RevealedBlank is the state of a visited cell with no neighboring mines.
+	RevealedBlank
+
+	// RevealedNumber is the state of a visited cell with a warning number.
+	RevealedNumber
+
+	// ExplodedMine is the state of a visited cell containing a mine.
+	ExplodedMine
+
+	// RevealedMine is the state of an unflagged cell containing a mine that
+	// is revealed once the game is lost.
+	RevealedMine
+
+	// WrongFlag is the state of a flagged cell with no mine that is revealed
+	// once the game is lost.
+	WrongFlag
+
+	// QuestionMarked is the state of a cell marked with a question mark by the
+	// player.
+	QuestionMarked
+)
+
+// Cell is a cell of the board as observed by the player. The Value is the
+// warning number of a cell whose state is minesweeper.RevealedNumber and zero
+// otherwise. In a game created with the WithMaxMinesPerCell(int) option, the
+// Value is also the number carried by the flag of a minesweeper.Flagged or
+// minesweeper.WrongFlag cell and the number of mines of a
+// minesweeper.ExplodedMine or minesweeper.RevealedMine cell.
+type Cell struct {
+	State CellState
+	Value int
+}
+
+// BoardView is the board as observed by the player, indexed by the
+// xy-coordinates of its cells. Unlike the rendering.Tracker interface, the
+// view never reveals the location of a mine or the value of a cell that the
+// player is not yet allowed to see. The layers of a board created with the
+// Grid3D option follow each other along the y-coordinate, so the cell at the
+// xyz-coordinates is at view[x][z*Height+y].
+type BoardView [][]Cell
+
+func (game *game) View() BoardView {
+	if game.Mutex != nil {
+		game.Lock()
+		defer game.Unlock()
+	}
+
+	if game.Grid == nil {
+		return nil
+	}
+
+	return game.view()
+}
+
+func (game *game) view() BoardView {
+	view := make(BoardView, game.Width)
+	for x := range view {
+		view[x] = make([]Cell, game.Height*game.layers())
+	}
+
+	game.iterateBlocks(func(block *Block) bool {
+		cell := block.observe(game.exploded)
+		if game.maxMinesPerCell() > 1 {
+			switch cell.State {
+			case Flagged, WrongFlag:
+				cell.Value = block.flags
+			case ExplodedMine, RevealedMine:
+				cell.Value = block.Mines()
+			}
+		}
+		view[block.X()][game.row(block)] = cell
+		return true
+	})
+
+	return view
+}
+
+func (block *Block) observe(lost bool) Cell {
+	switch {
+	case block.visited && block.Node == Bomb:
+		return Cell{State: ExplodedMine}
+	case block.visited && block.Node == Number:
+		return Cell{State: RevealedNumber, Value: block.Value}
+	case block.visited:
+		return Cell{State: RevealedBlank}
+	case block.flagged && lost && block.Node != Bomb:
+		return Cell{State: WrongFlag}
+	case block.flagged:
+		return Cell{State: Flagged}
+	case lost && block.Node == Bomb:
+		return Cell{State: RevealedMine}
+	case block.questioned:
+		return Cell{State: QuestionMarked}
+	default:
+		return Cell{State: Hidden}
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ * Minesweeper API
+ * Copyright (C) 2017  Ritchie Borja
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along
+ * with this program; if not, write to the Free Software Foundation, Inc.,
+ * 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package minesweeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewBeforeAnyMove(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	view := minesweeper.View()
+	assert.Equal(t, sampleGridWidth, len(view))
+	for _, column := range view {
+		assert.Equal(t, sampleGridHeight, len(column))
+		for _, cell := range column {
+			assert.Equal(t, Cell{State: Hidden}, cell)
+		}
+	}
+}
+
+func TestViewWithoutGrid(t *testing.T) {
+	assert.Nil(t, newBlankGame().View())
+}
+
+func TestViewOfRevealedAndFlaggedCells(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+	// The mines are flagged first since the game is won once the last safe cell
+	// is visited
+	for _, bomb := range game.BombLocations() {
+		minesweeper.Flag(bomb.X(), bomb.Y())
+	}
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node != Bomb {
+				minesweeper.Visit(x, y)
+			}
+		}
+	}
+
+	view := minesweeper.View()
+	for x, row := range game.blocks {
+		for y, block := range row {
+			switch block.Node {
+			case Bomb:
+				assert.Equal(t, Cell{State: Flagged}, view[x][y])
+			case Number:
+				assert.Equal(t, Cell{State: RevealedNumber, Value: block.Value}, view[x][y])
+			case Unknown:
+				assert.Equal(t, Cell{State: RevealedBlank}, view[x][y])
+			}
+		}
+	}
+}
+
+func TestViewHidesUnrevealedCells(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+mainLoop:
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node == Number {
+				minesweeper.Visit(x, y)
+				break mainLoop
+			}
+		}
+	}
+
+	view := minesweeper.View()
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if !block.visited {
+				assert.Equal(t, Cell{State: Hidden}, view[x][y])
+			}
+		}
+	}
+}
+
+func TestViewAfterLoss(t *testing.T) {
+	minesweeper := newSampleGame()
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	game := minesweeper.(*game)
+
+	var wrongFlag, exploded *Block
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node != Bomb && wrongFlag == nil {
+				minesweeper.Flag(x, y)
+				wrongFlag = &game.blocks[x][y]
+			}
+		}
+	}
+mainLoop:
+	for x, row := range game.blocks {
+		for y, block := range row {
+			if block.Node == Bomb {
+				minesweeper.Visit(x, y)
+				exploded = &game.blocks[x][y]
+				break mainLoop
+			}
+		}
+	}
+
+	view := minesweeper.View()
+	assert.Equal(t, Cell{State: WrongFlag}, view[wrongFlag.X()][wrongFlag.Y()])
+	assert.Equal(t, Cell{State: ExplodedMine}, view[exploded.X()][exploded.Y()])
+	for _, bomb := range game.BombLocations() {
+		if bomb.X() != exploded.X() || bomb.Y() != exploded.Y() {
+			assert.Equal(t, Cell{State: RevealedMine}, view[bomb.X()][bomb.Y()])
+		}
+	}
+}
+
+func TestViewOfQuestionMarkedCell(t *testing.T) {
+	minesweeper, _ := NewGame(Grid{sampleGridWidth, sampleGridHeight}, WithQuestionMarks())
+	minesweeper.SetDifficulty(Easy)
+	minesweeper.Play()
+
+	minesweeper.Flag(3, 6)
+	minesweeper.Flag(3, 6)
+
+	assert.Equal(t, Cell{State: QuestionMarked}, minesweeper.View()[3][6])
+}
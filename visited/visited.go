@@ -31,6 +31,19 @@ const (
 
 	// Bomb is the value of the cell with the mine
 	Bomb
+
+	// Flag is the action of flagging a cell
+	Flag
+
+	// QuestionMark is the action of marking a cell with a question mark
+	QuestionMark
+
+	// Unmark is the action of removing the flag or the question mark of a cell
+	Unmark
+
+	// Chord is the action of visiting all unflagged neighboring cells of a
+	// visited cell with a warning number
+	Chord
 )
 
 // History contains the information of all player's movements in a linked list
@@ -53,6 +66,10 @@ type Position interface {
 
 	// Y returns the y-coordinate of the cell in the grid
 	Y() int
+
+	// Z returns the layer of the cell in a three-dimensional grid, which is
+	// zero for a flat grid
+	Z() int
 }
 
 // StoryTeller is used to interface the instance of the Minesweeper game to retrieve